@@ -1,26 +1,85 @@
 package main
 
 import (
+	"context"
 	"github.com/SeiFlow-3P2/auth_service/internal/app"
+	"github.com/SeiFlow-3P2/auth_service/internal/handler/oauth"
+	"github.com/SeiFlow-3P2/auth_service/internal/handler/oidc"
 	"github.com/SeiFlow-3P2/auth_service/internal/service"
+	"io"
 	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 )
 
 const (
 	configPath = ".env" //читается в /internal/app/app.go  !!!!
+
+	shutdownTimeout = 10 * time.Second
 )
 
 func main() {
-	authApp := app.NewApp(configPath)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Фоновые задачи приложения завершаются вместе с сервером
+	authApp := app.NewApp(configPath, ctx.Done())
 	if authApp == nil {
 		panic("app is nil")
 	}
 
-	auth := service.Auth{authApp}
-	grpcServer := app.NewGRPCApp(slog.Default(), &auth, configPath)
+	auth := service.Auth{App: authApp}
+	grpcServer := app.NewGRPCApp(slog.Default(), &auth, authApp, configPath)
+	httpServer := app.NewHTTPApp(slog.Default(), grpcServer.Port(), configPath)
+	if httpServer != nil {
+		// Фронтенд отправляет браузер сюда, провайдеры возвращают его на RedirectURL из OauthConfigs
+		httpServer.Handle("GET /oauth/begin/{provider}", &oauth.Begin{
+			Auth:     &auth,
+			StateTTL: authApp.Settings.OAuthStateTTL,
+			Log:      slog.Default(),
+		})
+		httpServer.Handle("GET /callback/{provider}", &oauth.Callback{
+			Auth:        &auth,
+			FrontendURL: authApp.Settings.OAuthFrontendURL,
+			Log:         slog.Default(),
+		})
+		// client_credentials работает и с HS256, authorization_code без асимметричного ключа отклоняется
+		httpServer.Handle("POST /token", &oidc.Token{Auth: &auth, Log: slog.Default()})
+		// Провайдер OpenID Connect для своих приложений, ID токены подписываются только асимметричным ключом
+		if authApp.Settings.Keys != nil {
+			issuer := authApp.Settings.Issuer
+			httpServer.Handle("GET /.well-known/openid-configuration", &oidc.Discovery{Issuer: issuer, Keys: authApp.Settings.Keys})
+			httpServer.Handle("/authorize", &oidc.Authorize{Auth: &auth, Issuer: issuer, Log: slog.Default()})
+			httpServer.Handle("/userinfo", &oidc.UserInfo{Auth: &auth, Log: slog.Default()})
+		}
+	}
+
+	errs := make(chan error, 2)
+	go func() { errs <- grpcServer.Run() }()
+	if httpServer != nil {
+		go func() { errs <- httpServer.Run() }()
+	}
+
+	select {
+	case <-ctx.Done():
+	case err := <-errs:
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	// Сначала перестаём принимать HTTP запросы, они проксируются в gRPC
+	if httpServer != nil {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		httpServer.Stop(shutdownCtx)
+		cancel()
+	}
+	grpcServer.Stop()
 
-	err := grpcServer.Run()
-	if err != nil {
-		panic(err)
+	// Дожидаемся отправки писем из очереди
+	if closer, ok := authApp.Mailer.(io.Closer); ok {
+		_ = closer.Close()
 	}
 }
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/SeiFlow-3P2/auth_service/pkg/authKeys"
+)
+
+// keyrotate добавляет новый ключ подписи в JWT_KEYS_DIR.
+// Сервис подхватывает его при следующей перезагрузке ключей, публикует в JWKS
+// сразу, а подписывать начинает через -activate-in. Старые ключи проверяют
+// токены ещё -overlap после замены, -prune удаляет ключи, у которых это время вышло.
+func main() {
+	dir := flag.String("dir", os.Getenv("JWT_KEYS_DIR"), "keys directory")
+	alg := flag.String("alg", "ES256", "signing algorithm: RS256, ES256 or EdDSA")
+	// По умолчанию ключ начинает подписывать, когда его уже видят кеши JWKS (RemoteJWKS держит ключи 10 минут)
+	activateIn := flag.Duration("activate-in", 15*time.Minute, "delay before the new key starts signing, should exceed JWKS cache time of consumers")
+	overlap := flag.Duration("overlap", 0, "how long replaced keys keep verifying, the longest token ttl")
+	prune := flag.Bool("prune", false, "delete keys whose tokens have expired instead of adding a new key")
+	flag.Parse()
+
+	if *dir == "" {
+		fail("keys directory is not set")
+	}
+
+	if *prune {
+		if *overlap == 0 {
+			fail("-overlap is required with -prune")
+		}
+		removed, err := authKeys.PruneDir(*dir, *overlap)
+		if err != nil {
+			fail(err.Error())
+		}
+		for _, name := range removed {
+			fmt.Println("removed", name)
+		}
+		return
+	}
+
+	key, err := authKeys.GenerateKey(*alg)
+	if err != nil {
+		fail(err.Error())
+	}
+	data, err := key.MarshalPEM()
+	if err != nil {
+		fail(err.Error())
+	}
+
+	if err := os.MkdirAll(*dir, 0o700); err != nil {
+		fail(err.Error())
+	}
+	activeAt := time.Now().Add(*activateIn)
+	path := filepath.Join(*dir, authKeys.KeyFileName(key.ID, activeAt))
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		fail(err.Error())
+	}
+
+	fmt.Printf("created %s, kid %s, active from %s\n", path, key.ID, activeAt.Format(time.RFC3339))
+}
+
+func fail(msg string) {
+	fmt.Fprintln(os.Stderr, "keyrotate:", msg)
+	os.Exit(1)
+}
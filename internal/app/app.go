@@ -4,9 +4,17 @@ import (
 	"context"
 	"fmt"
 	"github.com/SeiFlow-3P2/auth_service/internal/domain"
+	"github.com/SeiFlow-3P2/auth_service/internal/middleware"
+	"github.com/SeiFlow-3P2/auth_service/pkg/authHash"
+	"github.com/SeiFlow-3P2/auth_service/pkg/authJWT"
+	"github.com/SeiFlow-3P2/auth_service/pkg/authKeys"
+	"github.com/SeiFlow-3P2/auth_service/pkg/authMail"
 	"github.com/SeiFlow-3P2/auth_service/pkg/authOrm"
 	"github.com/SeiFlow-3P2/auth_service/pkg/authRedis"
 	"github.com/SeiFlow-3P2/auth_service/pkg/grpc/auth_v1"
+	"github.com/SeiFlow-3P2/auth_service/pkg/oauth2/authOauth"
+	"github.com/SeiFlow-3P2/auth_service/pkg/utils/clientinfo"
+	"github.com/go-webauthn/webauthn/webauthn"
 	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
 	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/recovery"
 	"github.com/joho/godotenv"
@@ -22,10 +30,13 @@ import (
 	"net"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 )
 
-func NewApp(cfgPath string) *domain.App {
+// NewApp builds application from config. Background jobs, like reloading of
+// jwt signing keys, run until stop is closed.
+func NewApp(cfgPath string, stop <-chan struct{}) *domain.App {
 
 	//Загрузка переменных окружения
 	if err := godotenv.Load(cfgPath); err != nil {
@@ -45,7 +56,7 @@ func NewApp(cfgPath string) *domain.App {
 		panic(fmt.Sprintf("Error opening DB: %v", err))
 	}
 
-	authDB := &authOrm.AuthOrm{*db}
+	authDB := &authOrm.AuthOrm{DB: *db}
 	err = authDB.MigrateDB()
 
 	if err != nil {
@@ -67,11 +78,22 @@ func NewApp(cfgPath string) *domain.App {
 		panic("cant parse redis id")
 	}
 
+	keys, err := loadKeys(max(accessTTL, refreshTTL), stop)
+	if err != nil {
+		panic(fmt.Sprintf("cant load jwt signing keys: %v", err))
+	}
+
+	// SECRET нужен только без асимметричного ключа, либо чтобы принимать старые HS256 токены
 	secret := os.Getenv("SECRET")
-	if secret == "" {
+	if secret == "" && keys == nil {
 		panic("cant parse secret")
 	}
 
+	hasher, err := authHash.NewHasher(hashParams())
+	if err != nil {
+		panic(fmt.Sprintf("cant create password hasher: %v", err))
+	}
+
 	redis := authRedis.NewRedisClient(rdHost, rdPass, rdID, refreshTTL)
 
 	if redis == nil {
@@ -82,6 +104,72 @@ func NewApp(cfgPath string) *domain.App {
 	if appUrl == "" {
 		panic("cant parse app url")
 	}
+	// Разные окружения должны использовать разные issuer, чтобы токены не подходили друг к другу
+	issuer := os.Getenv("JWT_ISSUER")
+	if issuer == "" {
+		issuer = appUrl
+	}
+	audience := []string{issuer}
+	if aud := os.Getenv("JWT_AUDIENCE"); aud != "" {
+		audience = strings.Split(aud, ",")
+	}
+
+	verificationTTL := 24 * time.Hour
+	if ttl := os.Getenv("EMAIL_VERIFICATION_TTL"); ttl != "" {
+		verificationTTL, err = time.ParseDuration(ttl)
+		if err != nil {
+			panic("cant parse email verification ttl")
+		}
+	}
+	verificationURL := os.Getenv("EMAIL_VERIFICATION_URL")
+	if verificationURL == "" {
+		verificationURL = appUrl + "/verify-email?token="
+	}
+
+	resetTTL := 15 * time.Minute
+	if ttl := os.Getenv("PASSWORD_RESET_TTL"); ttl != "" {
+		resetTTL, err = time.ParseDuration(ttl)
+		if err != nil {
+			panic("cant parse password reset ttl")
+		}
+	}
+	resetURL := os.Getenv("PASSWORD_RESET_URL")
+	if resetURL == "" {
+		resetURL = appUrl + "/reset-password?token="
+	}
+
+	throttle := domain.ThrottleSettings{
+		Window:           envDuration("LOGIN_THROTTLE_WINDOW", 15*time.Minute),
+		MaxEmailFailures: envInt("LOGIN_MAX_EMAIL_FAILURES", 10),
+		MaxIPFailures:    envInt("LOGIN_MAX_IP_FAILURES", 50),
+		MaxMFAFailures:   envInt("LOGIN_MAX_MFA_FAILURES", 10),
+		FreeAttempts:     envInt("LOGIN_FREE_ATTEMPTS", 3),
+		BaseDelay:        envDuration("LOGIN_BASE_DELAY", time.Second),
+		Lockout:          envDuration("LOGIN_LOCKOUT", 15*time.Minute),
+	}
+
+	mfaIssuer := os.Getenv("MFA_ISSUER")
+	if mfaIssuer == "" {
+		mfaIssuer = "SeiFlow"
+	}
+
+	webAuthnTimeout := envDuration("WEBAUTHN_TIMEOUT", 5*time.Minute)
+	reauthWindow := envDuration("REAUTH_WINDOW", 5*time.Minute)
+	webAuthn, err := newWebAuthn(appUrl, mfaIssuer, webAuthnTimeout)
+	if err != nil {
+		panic(fmt.Sprintf("cant configure webauthn: %v", err))
+	}
+
+	oauthFrontendURL := os.Getenv("OAUTH_FRONTEND_URL")
+	if oauthFrontendURL == "" {
+		oauthFrontendURL = appUrl + "/oauth/complete"
+	}
+
+	oidcLoginURL := os.Getenv("IDP_LOGIN_URL")
+	if oidcLoginURL == "" {
+		oidcLoginURL = appUrl + "/oidc/login"
+	}
+
 	configs := make(map[string]*oauth2.Config)
 
 	configs["github"] = &oauth2.Config{
@@ -95,36 +183,222 @@ func NewApp(cfgPath string) *domain.App {
 	configs["google"] = &oauth2.Config{
 		ClientID:     os.Getenv("GOOGLE_CLIENT_ID"),
 		ClientSecret: os.Getenv("GOOGLE_CLIENT_SECRET"),
-		RedirectURL:  appUrl + "callback/google",
+		RedirectURL:  appUrl + "/callback/google",
 		Scopes:       []string{"https://www.googleapis.com/auth/userinfo.profile", "https://www.googleapis.com/auth/userinfo.email"},
 		Endpoint:     google.Endpoint,
 	}
 
+	providers := map[string]domain.OauthProvider{
+		"github": authOauth.NewGithub(configs["github"]),
+		"google": authOauth.NewGoogle(configs["google"]),
+	}
+
+	logger := slog.New(
+		slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}),
+	)
+
+	mailer, err := newMailer(logger)
+	if err != nil {
+		panic(fmt.Sprintf("cant create mailer: %v", err))
+	}
+	locale := os.Getenv("MAIL_DEFAULT_LOCALE")
+	if locale == "" {
+		locale = "ru"
+	}
+	templates, err := authMail.NewTemplates(locale)
+	if err != nil {
+		panic(fmt.Sprintf("cant load mail templates: %v", err))
+	}
+
+	// OIDC провайдеры с теми же именами заменяют встроенных
+	for name, provider := range newOIDCProviders(appUrl, logger) {
+		// Эндпоинты станут известны после discovery
+		configs[name] = &oauth2.Config{
+			ClientID:     provider.Config.ClientID,
+			ClientSecret: provider.Config.ClientSecret,
+			RedirectURL:  provider.Config.RedirectURL,
+			Scopes:       provider.Config.Scopes,
+		}
+		providers[name] = provider
+	}
+
 	return &domain.App{
 		AuthDB: authDB,
 		Casher: redis,
+		Hasher: hasher,
 		Settings: &domain.AppSettings{
 			Secret:     secret,
 			RefreshTTL: refreshTTL,
 			AccessTTL:  accessTTL,
+			Keys:       keys,
+			Issuer:     issuer,
+			Audience:   audience,
+
+			EmailVerificationTTL: verificationTTL,
+			EmailVerificationURL: verificationURL,
+			RequireVerifiedEmail: os.Getenv("REQUIRE_VERIFIED_EMAIL") == "true",
+			PasswordResetTTL:     resetTTL,
+			PasswordResetURL:     resetURL,
+			LoginThrottle:        throttle,
+			MFAIssuer:            mfaIssuer,
+			MFAChallengeTTL:      envDuration("MFA_CHALLENGE_TTL", 5*time.Minute),
+			WebAuthnTimeout:      webAuthnTimeout,
+			ReauthWindow:         reauthWindow,
+			TelegramBotToken:     os.Getenv("TELEGRAM_BOT_TOKEN"),
+			TelegramAuthMaxAge:   envDuration("TELEGRAM_AUTH_MAX_AGE", 10*time.Minute),
+			OAuthStateTTL:        envDuration("OAUTH_STATE_TTL", 10*time.Minute),
+			OAuthFrontendURL:     oauthFrontendURL,
+			LoginCodeTTL:         envDuration("LOGIN_CODE_TTL", time.Minute),
+			OIDCLoginURL:         oidcLoginURL,
+			OIDCRequestTTL:       envDuration("IDP_REQUEST_TTL", 10*time.Minute),
+			AuthorizationCodeTTL: envDuration("IDP_CODE_TTL", time.Minute),
 		},
-		Logger: slog.New(
-			slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}),
-		),
-		OauthConfigs: configs,
+		Logger:         logger,
+		OauthConfigs:   configs,
+		OauthProviders: providers,
+		Mailer:         mailer,
+		MailTemplates:  templates,
+		WebAuthn:       webAuthn,
 	}
 }
 
+// newWebAuthn configures passkeys. Returns nil if WEBAUTHN_RP_ID is not set.
+func newWebAuthn(appUrl string, name string, timeout time.Duration) (*webauthn.WebAuthn, error) {
+	rpID := os.Getenv("WEBAUTHN_RP_ID")
+	if rpID == "" {
+		return nil, nil
+	}
+	if rpName := os.Getenv("WEBAUTHN_RP_NAME"); rpName != "" {
+		name = rpName
+	}
+	origins := []string{appUrl}
+	if env := os.Getenv("WEBAUTHN_ORIGINS"); env != "" {
+		origins = strings.Split(env, ",")
+	}
+
+	// Таймаут проверяется и на сервере, браузер может его не соблюдать
+	timeouts := webauthn.TimeoutConfig{Enforce: true, Timeout: timeout, TimeoutUVD: timeout}
+	return webauthn.New(&webauthn.Config{
+		RPID:          rpID,
+		RPDisplayName: name,
+		RPOrigins:     origins,
+		Timeouts:      webauthn.TimeoutsConfig{Login: timeouts, Registration: timeouts},
+	})
+}
+
+// loadKeys loads jwt signing keys from JWT_KEYS_DIR or a single key from
+// JWT_PRIVATE_KEY_PATH / JWT_PRIVATE_KEY. Returns nil if none is configured.
+// Keys from directory are reloaded until stop is closed.
+func loadKeys(overlap time.Duration, stop <-chan struct{}) (*authKeys.Keyring, error) {
+	if dir := os.Getenv("JWT_KEYS_DIR"); dir != "" {
+		keys, err := authKeys.LoadDir(dir, overlap)
+		if err != nil {
+			return nil, err
+		}
+
+		// Новые ключи от keyrotate подхватываются без перезапуска
+		interval, err := time.ParseDuration(os.Getenv("JWT_KEYS_RELOAD_INTERVAL"))
+		if err != nil {
+			interval = time.Minute
+		}
+		go keys.WatchDir(dir, interval, stop, func(err error) {
+			slog.Error("cant reload jwt signing keys", slog.Any("err", err))
+		})
+		return keys, nil
+	}
+
+	var (
+		key *authKeys.Key
+		err error
+	)
+	if path := os.Getenv("JWT_PRIVATE_KEY_PATH"); path != "" {
+		key, err = authKeys.LoadKeyFile(path, os.Getenv("JWT_KEY_ID"))
+	} else if pemKey := os.Getenv("JWT_PRIVATE_KEY"); pemKey != "" {
+		key, err = authKeys.ParsePrivateKeyPEM([]byte(pemKey), os.Getenv("JWT_KEY_ID"))
+	} else {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if alg := os.Getenv("JWT_SIGNING_ALG"); alg != "" && alg != key.Method.Alg() {
+		return nil, fmt.Errorf("jwt signing key is %s, but JWT_SIGNING_ALG is %s", key.Method.Alg(), alg)
+	}
+	return authKeys.SingleKeyring(key), nil
+}
+
+// envDuration parses duration variable, empty value gives def
+func envDuration(name string, def time.Duration) time.Duration {
+	value := os.Getenv(name)
+	if value == "" {
+		return def
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		panic(fmt.Sprintf("cant parse %s", name))
+	}
+	return d
+}
+
+// envInt parses integer variable, empty value gives def
+func envInt(name string, def int) int {
+	value := os.Getenv(name)
+	if value == "" {
+		return def
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		panic(fmt.Sprintf("cant parse %s", name))
+	}
+	return n
+}
+
+// hashParams reads password hashing parameters, unset values fall back to defaults
+func hashParams() authHash.Params {
+	params := authHash.DefaultParams()
+
+	if algo := os.Getenv("PASSWORD_HASH_ALGO"); algo != "" {
+		params.Algo = algo
+	}
+	if v, err := strconv.ParseUint(os.Getenv("ARGON2_MEMORY_KB"), 10, 32); err == nil {
+		params.Memory = uint32(v)
+	}
+	if v, err := strconv.ParseUint(os.Getenv("ARGON2_ITERATIONS"), 10, 32); err == nil {
+		params.Iterations = uint32(v)
+	}
+	if v, err := strconv.ParseUint(os.Getenv("ARGON2_PARALLELISM"), 10, 8); err == nil {
+		params.Parallelism = uint8(v)
+	}
+	if v, err := strconv.Atoi(os.Getenv("BCRYPT_COST")); err == nil {
+		params.BcryptCost = v
+	}
+	return params
+}
+
 type App struct {
 	log        *slog.Logger
 	gRPCServer *grpc.Server
 	port       int
 }
 
+// defaultRateLimits ограничивают методы, которыми можно злоупотреблять без авторизации
+const defaultRateLimits = "/auth_v1.AuthService/Login=ip:20/1m;" +
+	"/auth_v1.AuthService/SignUp=ip:5/1h;" +
+	"/auth_v1.AuthService/RefreshToken=ip:60/1m;" +
+	"/auth_v1.AuthService/RequestPasswordReset=ip:5/1h;" +
+	"/auth_v1.AuthService/ResetPassword=ip:10/1h;" +
+	"/auth_v1.AuthService/ResendVerification=ip:5/1h;" +
+	"/auth_v1.AuthService/VerifyMFA=ip:30/1m;" +
+	"/auth_v1.AuthService/BeginPasskeyLogin=ip:30/1m;" +
+	"/auth_v1.AuthService/ExchangeLoginCode=ip:30/1m;" +
+	"/auth_v1.AuthService/IssueClientToken=ip:60/1m"
+
 // NewGRPCApp creates new gRPC server app.
 func NewGRPCApp(
 	log *slog.Logger,
 	authService auth_v1.Auth,
+	authApp *domain.App,
 	cfgPath string,
 ) *App {
 	if err := godotenv.Load(cfgPath); err != nil {
@@ -152,9 +426,47 @@ func NewGRPCApp(
 		}),
 	}
 
+	// Gateway подключается через loopback, поэтому он доверенный всегда
+	proxies := []string{"127.0.0.0/8", "::1"}
+	if env := os.Getenv("TRUSTED_PROXIES"); env != "" {
+		proxies = append(proxies, strings.Split(env, ",")...)
+	}
+	if err := clientinfo.SetTrustedProxies(proxies); err != nil {
+		panic(fmt.Sprintf("cant parse trusted proxies: %v", err))
+	}
+
+	rateLimits := os.Getenv("RATE_LIMITS")
+	if rateLimits == "" {
+		rateLimits = defaultRateLimits
+	}
+	policies, err := middleware.ParseRatePolicies(rateLimits)
+	if err != nil {
+		panic(fmt.Sprintf("cant parse rate limits: %v", err))
+	}
+	var limiter middleware.Limiter = middleware.NewMemoryLimiter()
+	if os.Getenv("RATE_LIMIT_BACKEND") != "memory" {
+		limiter = &middleware.RedisLimiter{Casher: authApp.Casher, Fallback: middleware.NewMemoryLimiter(), Log: log}
+	}
+	apiKeys, err := middleware.ParseAPIKeys(os.Getenv("RATE_LIMIT_API_KEYS"))
+	if err != nil {
+		panic(fmt.Sprintf("cant parse rate limit api keys: %v", err))
+	}
+	rateLimitCfg := middleware.RateLimitConfig{
+		Policies: policies,
+		APIKey:   apiKeys.Name,
+		UserID: func(accessToken string) (string, error) {
+			claims, err := authJWT.ParseToken(accessToken, authJWT.TokenTypeAccess, authApp.Settings)
+			if err != nil {
+				return "", err
+			}
+			return claims.Subject, nil
+		},
+	}
+
 	gRPCServer := grpc.NewServer(grpc.ChainUnaryInterceptor(
 		recovery.UnaryServerInterceptor(recoveryOpts...),
 		logging.UnaryServerInterceptor(InterceptorLogger(log), loggingOpts...),
+		middleware.RateLimitInterceptor(limiter, rateLimitCfg, log),
 	))
 
 	auth_v1.Register(gRPCServer, authService)
@@ -199,6 +511,11 @@ func (a *App) Run() error {
 	return nil
 }
 
+// Port returns port the gRPC server listens on.
+func (a *App) Port() int {
+	return a.port
+}
+
 // Stop stops gRPC server.
 func (a *App) Stop() {
 	const op = "grpcapp.Stop"
@@ -0,0 +1,228 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/SeiFlow-3P2/auth_service/internal/middleware"
+	authv1 "github.com/SeiFlow-3P2/auth_service/pkg/proto/v1"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/joho/godotenv"
+	rpccode "google.golang.org/genproto/googleapis/rpc/code"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type HTTPApp struct {
+	log        *slog.Logger
+	httpServer *http.Server
+	mux        *http.ServeMux
+	conn       *grpc.ClientConn
+	port       int
+}
+
+// NewHTTPApp creates HTTP/JSON gateway that proxies requests to the gRPC server on grpcPort.
+// Returns nil if HTTP_PORT is not set.
+func NewHTTPApp(log *slog.Logger, grpcPort int, cfgPath string) *HTTPApp {
+	if err := godotenv.Load(cfgPath); err != nil {
+		panic(fmt.Sprintf("Error loading .env file: %v", err))
+	}
+
+	if os.Getenv("HTTP_PORT") == "" {
+		return nil
+	}
+	httpPort, err := strconv.Atoi(os.Getenv("HTTP_PORT"))
+	if err != nil {
+		panic("cant parse http port")
+	}
+
+	// Запросы идут через обычный gRPC сервер, чтобы работали все его интерцепторы
+	conn, err := grpc.NewClient(fmt.Sprintf("localhost:%d", grpcPort),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		panic(fmt.Sprintf("cant create gateway connection: %v", err))
+	}
+
+	gateway := runtime.NewServeMux(
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{
+			MarshalOptions: protojson.MarshalOptions{
+				UseProtoNames:   true,
+				EmitUnpopulated: true,
+			},
+			UnmarshalOptions: protojson.UnmarshalOptions{
+				DiscardUnknown: true,
+			},
+		}),
+		runtime.WithErrorHandler(errorHandler),
+		runtime.WithIncomingHeaderMatcher(headerMatcher),
+	)
+	if err := authv1.RegisterAuthServiceHandler(context.Background(), gateway, conn); err != nil {
+		panic(fmt.Sprintf("cant register gateway: %v", err))
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/", gateway)
+
+	cors := corsConfig()
+	if err := cors.Validate(); err != nil {
+		panic(fmt.Sprintf("invalid cors config: %v", err))
+	}
+	handler := middleware.CORS(cors)(mux)
+
+	return &HTTPApp{
+		log: log,
+		httpServer: &http.Server{
+			Handler:           handler,
+			ReadHeaderTimeout: 10 * time.Second,
+		},
+		mux:  mux,
+		conn: conn,
+		port: httpPort,
+	}
+}
+
+// Handle registers additional plain HTTP handler next to the gateway
+func (a *HTTPApp) Handle(pattern string, handler http.Handler) {
+	a.mux.Handle(pattern, handler)
+}
+
+// headerMatcher forwards API key to gRPC metadata in addition to the default headers.
+// Адрес клиента gateway передаёт сам, подменить его через Grpc-Metadata-* нельзя.
+func headerMatcher(key string) (string, bool) {
+	if strings.EqualFold(key, "X-Api-Key") {
+		return "x-api-key", true
+	}
+	name, ok := runtime.DefaultHeaderMatcher(key)
+	if strings.EqualFold(name, "x-forwarded-for") || strings.EqualFold(name, "x-real-ip") {
+		return "", false
+	}
+	return name, ok
+}
+
+func corsConfig() middleware.CORSConfig {
+	split := func(value string, def []string) []string {
+		if value == "" {
+			return def
+		}
+		parts := strings.Split(value, ",")
+		for i := range parts {
+			parts[i] = strings.TrimSpace(parts[i])
+		}
+		return parts
+	}
+
+	maxAge, err := time.ParseDuration(os.Getenv("CORS_MAX_AGE"))
+	if err != nil {
+		maxAge = 10 * time.Minute
+	}
+
+	return middleware.CORSConfig{
+		AllowedOrigins:   split(os.Getenv("CORS_ALLOWED_ORIGINS"), nil),
+		AllowedMethods:   split(os.Getenv("CORS_ALLOWED_METHODS"), []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}),
+		AllowedHeaders:   split(os.Getenv("CORS_ALLOWED_HEADERS"), []string{"Authorization", "Content-Type", "Accept-Language", "X-Api-Key"}),
+		ExposedHeaders:   []string{"Retry-After"},
+		AllowCredentials: os.Getenv("CORS_ALLOW_CREDENTIALS") == "true",
+		MaxAge:           maxAge,
+	}
+}
+
+// httpError is JSON body of gateway errors
+type httpError struct {
+	Code    int               `json:"code"`
+	Status  string            `json:"status"`
+	Message string            `json:"message"`
+	Details []json.RawMessage `json:"details,omitempty"`
+}
+
+// errorHandler maps gRPC status to HTTP status and writes it as JSON
+func errorHandler(ctx context.Context, mux *runtime.ServeMux, marshaler runtime.Marshaler, w http.ResponseWriter, r *http.Request, err error) {
+	var customStatus *runtime.HTTPStatusError
+	if errors.As(err, &customStatus) {
+		err = customStatus.Err
+	}
+	st := status.Convert(err)
+
+	code := runtime.HTTPStatusFromCode(st.Code())
+	if customStatus != nil {
+		code = customStatus.HTTPStatus
+	}
+
+	body := httpError{
+		Code:    code,
+		Status:  rpccode.Code(st.Code()).String(),
+		Message: st.Message(),
+	}
+	for _, detail := range st.Proto().GetDetails() {
+		raw, err := protojson.Marshal(detail)
+		if err == nil {
+			body.Details = append(body.Details, raw)
+		}
+	}
+
+	if md, ok := runtime.ServerMetadataFromContext(ctx); ok {
+		for _, key := range []string{"retry-after"} {
+			if values := md.HeaderMD.Get(key); len(values) > 0 {
+				w.Header().Set(key, values[0])
+			} else if values := md.TrailerMD.Get(key); len(values) > 0 {
+				w.Header().Set(key, values[0])
+			}
+		}
+	}
+	if code == http.StatusUnauthorized {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+	}
+
+	w.Header().Del("Trailer")
+	w.Header().Del("Transfer-Encoding")
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// MustRun runs HTTP server and panics if any error occurs.
+func (a *HTTPApp) MustRun() {
+	if err := a.Run(); err != nil {
+		panic(err)
+	}
+}
+
+// Run runs HTTP server.
+func (a *HTTPApp) Run() error {
+	const op = "httpapp.Run"
+
+	l, err := net.Listen("tcp", fmt.Sprintf(":%d", a.port))
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	a.log.Info("http gateway started", slog.String("addr", l.Addr().String()))
+
+	if err := a.httpServer.Serve(l); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// Stop stops HTTP server waiting for active requests until ctx is done.
+func (a *HTTPApp) Stop(ctx context.Context) {
+	const op = "httpapp.Stop"
+
+	a.log.With(slog.String("op", op)).
+		Info("stopping HTTP server", slog.Int("port", a.port))
+
+	if err := a.httpServer.Shutdown(ctx); err != nil {
+		a.log.Error("HTTP server shutdown", slog.Any("err", err))
+	}
+	_ = a.conn.Close()
+}
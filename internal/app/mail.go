@@ -0,0 +1,54 @@
+package app
+
+import (
+	"fmt"
+	"github.com/SeiFlow-3P2/auth_service/internal/domain"
+	"github.com/SeiFlow-3P2/auth_service/pkg/authMail"
+	"log/slog"
+	"os"
+	"strconv"
+	"time"
+)
+
+// newMailer creates mail sender from MAIL_BACKEND: smtp, outbox or log.
+// SMTP and outbox senders are wrapped in async queue with retries.
+func newMailer(log *slog.Logger) (domain.MailSender, error) {
+	from := os.Getenv("MAIL_FROM")
+
+	var sender domain.MailSender
+	switch backend := os.Getenv("MAIL_BACKEND"); backend {
+	case "", "log":
+		return authMail.LogSender{Logger: log}, nil
+	case "smtp":
+		port, err := strconv.Atoi(os.Getenv("SMTP_PORT"))
+		if err != nil {
+			return nil, fmt.Errorf("cant parse smtp port: %w", err)
+		}
+		sender = &authMail.SMTPSender{
+			Host:     os.Getenv("SMTP_HOST"),
+			Port:     port,
+			Username: os.Getenv("SMTP_USERNAME"),
+			Password: os.Getenv("SMTP_PASSWORD"),
+			From:     from,
+			TLS:      os.Getenv("SMTP_TLS"),
+		}
+	case "outbox":
+		dir := os.Getenv("MAIL_OUTBOX_DIR")
+		if dir == "" {
+			dir = "outbox"
+		}
+		sender = &authMail.OutboxSender{Dir: dir, From: from}
+	default:
+		return nil, fmt.Errorf("unknown mail backend %q", backend)
+	}
+	if from == "" {
+		return nil, fmt.Errorf("MAIL_FROM is required")
+	}
+
+	cfg := authMail.QueueConfig{}
+	cfg.Size, _ = strconv.Atoi(os.Getenv("MAIL_QUEUE_SIZE"))
+	cfg.Workers, _ = strconv.Atoi(os.Getenv("MAIL_QUEUE_WORKERS"))
+	cfg.MaxAttempts, _ = strconv.Atoi(os.Getenv("MAIL_MAX_ATTEMPTS"))
+	cfg.Backoff, _ = time.ParseDuration(os.Getenv("MAIL_RETRY_BACKOFF"))
+	return authMail.NewQueue(sender, cfg, log), nil
+}
@@ -0,0 +1,59 @@
+package app
+
+import (
+	"context"
+	"github.com/SeiFlow-3P2/auth_service/pkg/oauth2/authOauth"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+)
+
+// newOIDCProviders discovers OpenID Connect providers listed in OIDC_PROVIDERS.
+// Each provider <name> is configured by OIDC_<NAME>_* variables. Provider that
+// can't be discovered at startup is discovered again when it is used, so one
+// unavailable IdP doesn't stop the service.
+func newOIDCProviders(appUrl string, log *slog.Logger) map[string]*authOauth.LazyOIDC {
+	providers := make(map[string]*authOauth.LazyOIDC)
+	if os.Getenv("OIDC_PROVIDERS") == "" {
+		return providers
+	}
+
+	for _, name := range strings.Split(os.Getenv("OIDC_PROVIDERS"), ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		env := func(key string) string {
+			return os.Getenv("OIDC_" + strings.ToUpper(name) + "_" + key)
+		}
+
+		cfg := authOauth.OIDCConfig{
+			Name:         name,
+			Issuer:       env("ISSUER"),
+			ClientID:     env("CLIENT_ID"),
+			ClientSecret: env("CLIENT_SECRET"),
+			RedirectURL:  appUrl + "/callback/" + name,
+			Claims: authOauth.ClaimMapping{
+				ID:            env("CLAIM_ID"),
+				Name:          env("CLAIM_NAME"),
+				Email:         env("CLAIM_EMAIL"),
+				EmailVerified: env("CLAIM_EMAIL_VERIFIED"),
+				AvatarURL:     env("CLAIM_PICTURE"),
+				TrustEmail:    env("TRUST_EMAIL") == "true",
+			},
+		}
+		if scopes := env("SCOPES"); scopes != "" {
+			cfg.Scopes = strings.Split(scopes, ",")
+		}
+
+		provider := authOauth.NewLazyOIDC(cfg)
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		if err := provider.Ready(ctx); err != nil {
+			log.Error("cant discover oidc provider, will retry on login", slog.String("provider", name), slog.String("err", err.Error()))
+		}
+		cancel()
+		providers[name] = provider
+	}
+	return providers
+}
@@ -1,8 +1,12 @@
 package domain
 
 import (
+	"context"
+	"github.com/SeiFlow-3P2/auth_service/pkg/authHash"
+	"github.com/SeiFlow-3P2/auth_service/pkg/authKeys"
 	"github.com/SeiFlow-3P2/auth_service/pkg/authRedis"
 	authv1 "github.com/SeiFlow-3P2/auth_service/pkg/proto/v1"
+	"github.com/go-webauthn/webauthn/webauthn"
 	"github.com/google/uuid"
 	"golang.org/x/oauth2"
 	"log/slog"
@@ -12,26 +16,127 @@ import (
 type App struct {
 	Casher       *authRedis.Casher
 	AuthDB       AuthDB
+	Hasher       *authHash.Hasher
 	GrpcServer   *authv1.AuthServiceServer
 	Settings     *AppSettings
 	Logger       *slog.Logger
 	OauthConfigs map[string]*oauth2.Config
+	// OauthProviders содержит провайдеров по тем же ключам, что и OauthConfigs
+	OauthProviders map[string]OauthProvider
+	Mailer         MailSender
+	MailTemplates  MailRenderer
+	// WebAuthn nil, если вход по passkey не настроен
+	WebAuthn *webauthn.WebAuthn
 }
 
 type AppSettings struct {
 	Secret     string
 	RefreshTTL time.Duration
 	AccessTTL  time.Duration
+	// Keys если заданы, токены подписываются активным ключом вместо Secret
+	Keys *authKeys.Keyring
+	// Issuer и Audience попадают в iss и aud access токенов пользователя и проверяются при разборе,
+	// токены приложений и сервисов адресованы их client_id
+	Issuer   string
+	Audience []string
+	// EmailVerificationTTL время жизни ссылки подтверждения email
+	EmailVerificationTTL time.Duration
+	// EmailVerificationURL адрес страницы подтверждения, к нему дописывается токен
+	EmailVerificationURL string
+	// RequireVerifiedEmail запрещает вход по паролю до подтверждения email
+	RequireVerifiedEmail bool
+	// PasswordResetTTL время жизни ссылки восстановления пароля
+	PasswordResetTTL time.Duration
+	// PasswordResetURL адрес страницы смены пароля, к нему дописывается токен
+	PasswordResetURL string
+	// LoginThrottle ограничения на неудачные попытки входа по паролю
+	LoginThrottle ThrottleSettings
+	// MFAIssuer название сервиса в приложении-аутентификаторе
+	MFAIssuer string
+	// MFAChallengeTTL сколько ждать код второго фактора после пароля
+	MFAChallengeTTL time.Duration
+	// WebAuthnTimeout сколько ждать ответ аутентификатора при регистрации и входе по passkey
+	WebAuthnTimeout time.Duration
+	// ReauthWindow сколько после входа можно менять passkeys без пароля и кода MFA
+	ReauthWindow time.Duration
+	// TelegramBotToken токен бота для проверки Telegram Login Widget, пусто — вход через Telegram выключен
+	TelegramBotToken string
+	// TelegramAuthMaxAge насколько старые данные виджета принимаются
+	TelegramAuthMaxAge time.Duration
+	// OAuthStateTTL сколько ждать возврата пользователя от OAuth провайдера
+	OAuthStateTTL time.Duration
+	// OAuthFrontendURL куда callback перенаправляет с одноразовым кодом входа
+	OAuthFrontendURL string
+	// LoginCodeTTL время жизни одноразового кода входа после callback
+	LoginCodeTTL time.Duration
+	// OIDCLoginURL страница входа и согласия для приложений, которые входят через этот сервис, к ней дописывается request_id
+	OIDCLoginURL string
+	// OIDCRequestTTL сколько ждать входа и согласия пользователя после /authorize
+	OIDCRequestTTL time.Duration
+	// AuthorizationCodeTTL время жизни кода авторизации, который клиент обменивает на /token
+	AuthorizationCodeTTL time.Duration
+}
+
+// ThrottleSettings limits failed logins per email and per client IP and failed second factor
+// codes per user within sliding window. After FreeAttempts failures every next one locks
+// login for BaseDelay doubling each time, reaching the max failures locks it for Lockout.
+type ThrottleSettings struct {
+	Window           time.Duration
+	MaxEmailFailures int
+	MaxIPFailures    int
+	// MaxMFAFailures считается по пользователю во всех челленджах, новый вход по паролю его не сбрасывает
+	MaxMFAFailures int
+	FreeAttempts   int
+	BaseDelay      time.Duration
+	Lockout        time.Duration
+}
+
+// OauthProvider exchanges authorization codes and fetches user profiles from an external provider
+type OauthProvider interface {
+	AuthCodeURL(state string, opts ...oauth2.AuthCodeOption) string
+	Exchange(ctx context.Context, code string, opts ...oauth2.AuthCodeOption) (*oauth2.Token, error)
+	UserInfo(ctx context.Context, token *oauth2.Token) (*UserInfo, error)
+}
+
+// OauthProviderReady is implemented by providers that may be unavailable, e.g. OpenID Connect
+// provider not discovered yet. AuthCodeURL is called only after Ready succeeds.
+type OauthProviderReady interface {
+	Ready(ctx context.Context) error
 }
 
 type AuthDB interface {
-	CreateUser(name string, email string, photoUrl string, telegramId uint, password []byte) error
-	ChangePassword(userId uuid.UUID, password []byte) error
+	CreateUser(name string, email string, photoUrl string, telegramId uint, passwordHash []byte) error
+	ChangePassword(userId uuid.UUID, passwordHash []byte) error
 	ChangeEmail(userId uuid.UUID, email string) error
+	ChangeUsername(userId uuid.UUID, username string) error
 	ChangePhoto(userId uuid.UUID, photoUrl string) error
 	ChangeTelegramId(userId uuid.UUID, telegramId uint) error
+	GetUserByTelegramId(telegramId uint) (*User, error)
+	SetTelegramVerified(userId uuid.UUID, telegramId uint) error
+	CreateIdentity(identity *Identity) error
+	GetIdentity(provider string, subject string) (*Identity, error)
+	GetIdentities(userId uuid.UUID) ([]Identity, error)
+	DeleteIdentity(userId uuid.UUID, id uuid.UUID) error
+	SetEmailVerified(userId uuid.UUID, verifiedAt time.Time) error
 	GetUser(userId uuid.UUID) (*User, error)
 	GetUserByEmail(email string) (*User, error)
+	GetUserByUsername(username string) (*User, error)
+	CreateSecurityEvent(event *SecurityEvent) error
+	GetMFA(userId uuid.UUID) (*UserMFA, error)
+	SaveMFA(mfa *UserMFA) error
+	UseMFACounter(userId uuid.UUID, counter int64) (bool, error)
+	DeleteMFA(userId uuid.UUID) error
+	ReplaceRecoveryCodes(userId uuid.UUID, codeHashes [][]byte) error
+	UseRecoveryCode(userId uuid.UUID, codeHash []byte) (bool, error)
+	CreateWebAuthnCredential(credential *WebAuthnCredential) error
+	GetWebAuthnCredentials(userId uuid.UUID) ([]WebAuthnCredential, error)
+	DeleteWebAuthnCredential(userId uuid.UUID, id uuid.UUID) error
+	UpdateWebAuthnCredentialUsage(id uuid.UUID, signCount uint32, backupState bool, usedAt time.Time) error
+	CreateOAuthClient(client *OAuthClient) error
+	GetOAuthClient(clientID string) (*OAuthClient, error)
+	DeleteOAuthClient(clientID string) error
+	GetOAuthConsent(userId uuid.UUID, clientID string) (*OAuthConsent, error)
+	SaveOAuthConsent(consent *OAuthConsent) error
 	Ping() error
 	MigrateDB() error
 }
@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrUnauthenticated  = errors.New("unauthenticated")
+	ErrSessionNotFound  = errors.New("session not found")
+	ErrInvalidToken     = errors.New("invalid token")
+	ErrTokenReused      = errors.New("refresh token reused")
+	ErrEmailNotVerified = errors.New("email not verified")
+	ErrInvalidPassword  = errors.New("invalid password")
+	ErrEmailTaken       = errors.New("email already taken")
+	ErrAccountExists    = errors.New("account with unconfirmed email exists")
+	ErrUsernameTaken    = errors.New("username already taken")
+	ErrForbidden        = errors.New("forbidden")
+	ErrTooManyAttempts  = errors.New("too many attempts")
+	ErrMFANotEnabled    = errors.New("mfa not enabled")
+	ErrMFAEnabled       = errors.New("mfa already enabled")
+	ErrInvalidMFACode   = errors.New("invalid mfa code")
+	ErrWebAuthnDisabled = errors.New("webauthn is not configured")
+	ErrInvalidPasskey   = errors.New("invalid passkey")
+	ErrPasskeyNotFound  = errors.New("passkey not found")
+	ErrReauthRequired   = errors.New("recent login or password required")
+	ErrTelegramDisabled = errors.New("telegram login is not configured")
+	ErrInvalidTelegram  = errors.New("invalid telegram auth data")
+	ErrIdentityLinked   = errors.New("identity is linked to another user")
+	ErrIdentityNotFound = errors.New("identity not found")
+	ErrLastLoginMethod  = errors.New("can't remove the last login method")
+	ErrUnknownProvider  = errors.New("unknown provider")
+	ErrProviderDown     = errors.New("provider is unavailable")
+	ErrOIDCDisabled     = errors.New("openid connect provider is not configured")
+	ErrInvalidClient    = errors.New("invalid client")
+	ErrInvalidRedirect  = errors.New("redirect uri is not registered")
+	ErrRequestNotFound  = errors.New("authorization request not found")
+	ErrClientMetadata   = errors.New("invalid client metadata")
+)
+
+// RetryError is returned when request is throttled, errors.Is matches ErrTooManyAttempts
+type RetryError struct {
+	RetryAfter time.Duration
+}
+
+func (e *RetryError) Error() string {
+	return "too many attempts, retry after " + e.RetryAfter.String()
+}
+
+func (e *RetryError) Unwrap() error {
+	return ErrTooManyAttempts
+}
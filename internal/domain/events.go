@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+const (
+	EventRefreshTokenReuse = "refresh_token_reuse"
+	EventPasswordReset     = "password_reset"
+	EventPasswordChanged   = "password_changed"
+	EventEmailChanged      = "email_changed"
+	EventProfileUpdated    = "profile_updated"
+	EventLoginLockout      = "login_lockout"
+	EventMFALockout        = "mfa_lockout"
+	EventMFAEnabled        = "mfa_enabled"
+	EventMFADisabled       = "mfa_disabled"
+	EventRecoveryCodeUsed  = "mfa_recovery_code_used"
+	EventPasskeyAdded      = "passkey_added"
+	EventPasskeyRemoved    = "passkey_removed"
+	EventPasskeyCloned     = "passkey_clone_warning"
+	EventIdentityLinked    = "identity_linked"
+	EventIdentityUnlinked  = "identity_unlinked"
+	EventOAuthConsent      = "oauth_consent_granted"
+)
+
+// SecurityEvent is an audit record of suspicious or security relevant activity on the account
+type SecurityEvent struct {
+	ID        uuid.UUID `gorm:"primaryKey;not null"`
+	CreatedAt time.Time `gorm:"not null"`
+	UserID    uuid.UUID `gorm:"type:uuid;index;not null"`
+	Type      string    `gorm:"size:64;not null"`
+	SessionID string    `gorm:"size:64"`
+	IP        string    `gorm:"size:64"`
+	UserAgent string    `gorm:"size:255"`
+	Details   string    `gorm:"size:1024"`
+}
@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// Провайдеры входа, которые не являются OAuth провайдерами из OauthProviders
+const (
+	ProviderEmail    = "email"
+	ProviderTelegram = "telegram"
+)
+
+// Identity is external account (GitHub, Google, Telegram...) linked to the user.
+// One external account can belong only to one user.
+type Identity struct {
+	ID       uuid.UUID `gorm:"primaryKey;not null"`
+	UserID   uuid.UUID `gorm:"type:uuid;index;not null"`
+	Provider string    `gorm:"size:64;not null;uniqueIndex:idx_identity_subject"`
+	// Subject id пользователя у провайдера
+	Subject  string    `gorm:"size:255;not null;uniqueIndex:idx_identity_subject"`
+	Email    string    `gorm:"type:varchar(100)"`
+	LinkedAt time.Time `gorm:"not null"`
+}
+
+func (Identity) TableName() string {
+	return "user_identities"
+}
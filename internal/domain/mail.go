@@ -0,0 +1,21 @@
+package domain
+
+import "context"
+
+// Mail is an outgoing email
+type Mail struct {
+	To      string
+	Subject string
+	Text    string
+	HTML    string
+}
+
+// MailSender delivers emails, implementations live in pkg/authMail
+type MailSender interface {
+	Send(ctx context.Context, mail Mail) error
+}
+
+// MailRenderer renders localized email templates, To is left empty
+type MailRenderer interface {
+	Render(name string, locale string, data any) (Mail, error)
+}
@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// UserMFA is TOTP second factor of the user. Until confirmed with the first
+// code it doesn't take part in login.
+type UserMFA struct {
+	UserID      uuid.UUID `gorm:"type:uuid;primaryKey"`
+	CreatedAt   time.Time `gorm:"not null"`
+	UpdatedAt   time.Time `gorm:"not null"`
+	Secret      string    `gorm:"size:64;not null"`
+	ConfirmedAt *time.Time
+	// LastCounter шаг времени последнего принятого кода, повторно код не принимается
+	LastCounter int64 `gorm:"not null;default:0"`
+}
+
+func (UserMFA) TableName() string {
+	return "user_mfa"
+}
+
+// Enabled reports whether MFA is confirmed and required on login
+func (m *UserMFA) Enabled() bool {
+	return m != nil && m.ConfirmedAt != nil
+}
+
+// RecoveryCode is one-time code to pass MFA without authenticator
+type RecoveryCode struct {
+	ID        uuid.UUID `gorm:"primaryKey;not null"`
+	CreatedAt time.Time `gorm:"not null"`
+	UserID    uuid.UUID `gorm:"type:uuid;index;not null"`
+	CodeHash  []byte    `gorm:"not null"`
+	UsedAt    *time.Time
+}
+
+func (RecoveryCode) TableName() string {
+	return "mfa_recovery_codes"
+}
+
+// MFARequiredError is returned by login when the user has to pass second factor.
+// Challenge is passed to VerifyMFA together with the code or to VerifyMFAPasskey.
+type MFARequiredError struct {
+	UserID    uuid.UUID
+	Challenge string
+	ExpiresAt time.Time
+}
+
+func (e *MFARequiredError) Error() string {
+	return "mfa required"
+}
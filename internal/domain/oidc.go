@@ -0,0 +1,159 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Скоупы OpenID Connect, которые сервис выдаёт своим клиентам
+const (
+	ScopeOpenID  = "openid"
+	ScopeProfile = "profile"
+	ScopeEmail   = "email"
+)
+
+// ScopeIntrospect allows service client to call Introspect
+const ScopeIntrospect = "introspect"
+
+// ScopeUsers allows service client to read any user with GetUserInfo
+const ScopeUsers = "users"
+
+// Способы получения токенов зарегистрированными клиентами
+const (
+	GrantAuthorizationCode = "authorization_code"
+	GrantClientCredentials = "client_credentials"
+)
+
+// OAuthClient is an application registered to log users in through this service (OpenID Connect relying party)
+// or a backend service getting its own tokens with client_credentials grant
+type OAuthClient struct {
+	ID        uuid.UUID `gorm:"primaryKey;not null"`
+	CreatedAt time.Time `gorm:"not null"`
+	UpdatedAt time.Time `gorm:"not null"`
+	ClientID  string    `gorm:"size:64;uniqueIndex;not null"`
+	// SecretHash sha256 секрета, пусто у публичных клиентов (SPA, мобильные приложения) — им обязателен PKCE
+	SecretHash string `gorm:"size:64"`
+	Name       string `gorm:"size:255;not null"`
+	// RedirectURIs и Scopes через пробел, redirect_uri сравнивается целиком
+	RedirectURIs string `gorm:"size:2048;not null"`
+	Scopes       string `gorm:"size:512;not null"`
+	// SkipConsent для собственных приложений: пользователя не спрашивают о доступе
+	SkipConsent bool `gorm:"not null;default:false"`
+	// GrantTypes через пробел, клиенты до появления client_credentials входят только по коду
+	GrantTypes string `gorm:"size:128;not null;default:authorization_code"`
+	// TokenTTL время жизни токенов client_credentials, 0 — как у access токенов пользователей
+	TokenTTL time.Duration `gorm:"not null;default:0"`
+}
+
+func (OAuthClient) TableName() string {
+	return "oauth_clients"
+}
+
+// Public reports whether client can't keep a secret
+func (c *OAuthClient) Public() bool {
+	return c.SecretHash == ""
+}
+
+// AllowsRedirect reports whether uri is one of registered redirect uris
+func (c *OAuthClient) AllowsRedirect(uri string) bool {
+	return uri != "" && slices.Contains(strings.Fields(c.RedirectURIs), uri)
+}
+
+// AllowsGrant reports whether client may get tokens with the grant type
+func (c *OAuthClient) AllowsGrant(grantType string) bool {
+	return slices.Contains(strings.Fields(c.GrantTypes), grantType)
+}
+
+// AllowedScopes returns scopes the client may request
+func (c *OAuthClient) AllowedScopes() []string {
+	return strings.Fields(c.Scopes)
+}
+
+// ClientRegistration holds metadata of the new client, empty GrantTypes means authorization_code
+type ClientRegistration struct {
+	Name         string
+	RedirectURIs []string
+	Scopes       []string
+	GrantTypes   []string
+	Public       bool
+	SkipConsent  bool
+	TokenTTL     time.Duration
+}
+
+// OAuthConsent remembers scopes the user allowed to the client, the consent page is not shown again for them
+type OAuthConsent struct {
+	UserID    uuid.UUID `gorm:"type:uuid;primaryKey"`
+	ClientID  string    `gorm:"size:64;primaryKey"`
+	Scopes    string    `gorm:"size:512;not null"`
+	GrantedAt time.Time `gorm:"not null"`
+}
+
+func (OAuthConsent) TableName() string {
+	return "oauth_consents"
+}
+
+// Covers reports whether the consent includes all scopes
+func (c *OAuthConsent) Covers(scopes []string) bool {
+	granted := strings.Fields(c.Scopes)
+	for _, scope := range scopes {
+		if !slices.Contains(granted, scope) {
+			return false
+		}
+	}
+	return true
+}
+
+// AuthorizationRequest is OpenID Connect authorization request waiting for the user login and consent
+type AuthorizationRequest struct {
+	ClientID    string   `json:"client_id"`
+	ClientName  string   `json:"client_name"`
+	RedirectURI string   `json:"redirect_uri"`
+	Scopes      []string `json:"scopes"`
+	State       string   `json:"state,omitempty"`
+	Nonce       string   `json:"nonce,omitempty"`
+	// CodeChallenge PKCE S256, обязателен для публичных клиентов
+	CodeChallenge string `json:"code_challenge,omitempty"`
+	// ForceConsent prompt=consent: спросить пользователя, даже если он уже разрешал
+	ForceConsent bool `json:"force_consent,omitempty"`
+	// ConsentRequired вычисляется для текущего пользователя, не хранится
+	ConsentRequired bool `json:"-"`
+
+	// Параметры запроса, которые проверяются в /authorize и не хранятся
+	ResponseType        string `json:"-"`
+	CodeChallengeMethod string `json:"-"`
+	Prompt              string `json:"-"`
+}
+
+// AuthorizationGrant is what the authorization code stands for until the client exchanges it
+type AuthorizationGrant struct {
+	UserID        string    `json:"user_id"`
+	ClientID      string    `json:"client_id"`
+	RedirectURI   string    `json:"redirect_uri"`
+	Scopes        []string  `json:"scopes"`
+	Nonce         string    `json:"nonce,omitempty"`
+	CodeChallenge string    `json:"code_challenge,omitempty"`
+	AuthTime      time.Time `json:"auth_time"`
+}
+
+// OIDCTokens is a response of the token endpoint, client_credentials grant has no ID token
+type OIDCTokens struct {
+	AccessToken string
+	IDToken     string
+	ExpiresIn   time.Duration
+	Scopes      []string
+}
+
+// OAuthError is an error of the authorize and token endpoints returned to the client as is (RFC 6749 4.1.2.1, 5.2)
+type OAuthError struct {
+	Code        string
+	Description string
+}
+
+func (e *OAuthError) Error() string {
+	if e.Description == "" {
+		return e.Code
+	}
+	return e.Code + ": " + e.Description
+}
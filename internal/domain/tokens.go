@@ -1,6 +1,27 @@
 package domain
 
+import "time"
+
 type Tokens struct {
 	AccessToken  string
 	RefreshToken string
+	// RefreshTokenID is jti of the refresh token, the session remembers only the latest one
+	RefreshTokenID string
+}
+
+// TokenInfo is a result of token introspection, only Active is set for invalid tokens
+type TokenInfo struct {
+	Active     bool
+	TokenType  string
+	UserID     string
+	Email      string
+	Username   string
+	TelegramID uint
+	Scopes     []string
+	SessionID  string
+	// ClientID приложения или сервиса, которому выдан токен; у сервисов UserID пуст
+	ClientID    string
+	SubjectType string
+	IssuedAt    time.Time
+	ExpiresAt   time.Time
 }
@@ -6,19 +6,42 @@ import (
 )
 
 type User struct {
-	ID           uuid.UUID `gorm:"primaryKey;not null"`
-	CreatedAt    time.Time `gorm:"not null"`
-	UpdatedAt    time.Time `gorm:"not null"`
-	Username     string    `gorm:"size:255;uniqueIndex;not null"`
-	Email        string    `gorm:"type:varchar(100);uniqueIndex;not null"`
-	PhotoUrl     string    `gorm:"size:255;default:null"`
-	TelegramId   uint      `gorm:"size:11"`
-	PasswordHash []byte
+	ID         uuid.UUID `gorm:"primaryKey;not null"`
+	CreatedAt  time.Time `gorm:"not null"`
+	UpdatedAt  time.Time `gorm:"not null"`
+	Username   string    `gorm:"size:255;uniqueIndex;not null"`
+	Email      string    `gorm:"type:varchar(100);uniqueIndex;not null"`
+	PhotoUrl   string    `gorm:"size:255;default:null"`
+	TelegramId uint      `gorm:"size:11"`
+	// TelegramVerified true, если TelegramId подтверждён через Telegram Login Widget, а не просто указан клиентом
+	TelegramVerified bool `gorm:"not null;default:false"`
+	PasswordHash     []byte
+	// VerifiedAt время подтверждения текущего email, nil — не подтверждён
+	VerifiedAt *time.Time `gorm:"default:null"`
+	// IsAdmin выставляется вручную в БД, даёт scope admin в access токенах
+	IsAdmin bool `gorm:"not null;default:false"`
 }
+
+// ScopeAdmin is scope of access tokens of admin users
+const ScopeAdmin = "admin"
+
+// EmailVerified reports whether user confirmed his current email
+func (u *User) EmailVerified() bool {
+	return u.VerifiedAt != nil
+}
+
+// ProfileUpdate holds profile fields to change, nil fields are left as is
+type ProfileUpdate struct {
+	Username   *string
+	PhotoUrl   *string
+	TelegramID *uint
+}
+
 type UserInfo struct {
-	ID        string
-	Name      string
-	Email     string
-	AvatarURL string
-	Provider  string
+	ID            string
+	Name          string
+	Email         string
+	EmailVerified bool
+	AvatarURL     string
+	Provider      string
 }
@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// WebAuthnCredential is passkey or security key of the user
+type WebAuthnCredential struct {
+	ID              uuid.UUID `gorm:"primaryKey;not null"`
+	CreatedAt       time.Time `gorm:"not null"`
+	UpdatedAt       time.Time `gorm:"not null"`
+	UserID          uuid.UUID `gorm:"type:uuid;index;not null"`
+	Name            string    `gorm:"size:255"`
+	CredentialID    []byte    `gorm:"uniqueIndex;not null"`
+	PublicKey       []byte    `gorm:"not null"`
+	AttestationType string    `gorm:"size:64"`
+	Transports      string    `gorm:"size:255"` // через запятую
+	AAGUID          []byte
+	// SignCount последнее значение счётчика аутентификатора, уменьшение означает клон ключа
+	SignCount      uint32 `gorm:"not null;default:0"`
+	UserVerified   bool
+	BackupEligible bool
+	BackupState    bool
+	LastUsedAt     *time.Time
+}
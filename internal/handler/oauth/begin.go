@@ -0,0 +1,50 @@
+package oauth
+
+import (
+	"errors"
+	"github.com/SeiFlow-3P2/auth_service/internal/domain"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// Begin starts login through OAuth provider (GET /oauth/begin/{provider}): binds
+// the login to the browser with a cookie and redirects it to the provider consent page.
+type Begin struct {
+	Auth Auth
+	// StateTTL срок жизни cookie, как у state
+	StateTTL time.Duration
+	Log      *slog.Logger
+}
+
+func (h *Begin) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	op := "OAuth_Begin: "
+
+	authURL, binding, err := h.Auth.BeginOAuth(r.Context(), r.PathValue("provider"))
+	if err != nil {
+		if errors.Is(err, domain.ErrUnknownProvider) {
+			http.Error(w, "unknown provider", http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, domain.ErrProviderDown) {
+			http.Error(w, "provider is unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		h.Log.Error(op, slog.String(op, err.Error()))
+		http.Error(w, "failed to begin oauth", http.StatusInternalServerError)
+		return
+	}
+
+	// Lax: cookie уходит при переходе с сайта провайдера на callback
+	http.SetCookie(w, &http.Cookie{
+		Name:     bindingCookie,
+		Value:    binding,
+		Path:     bindingCookiePath,
+		MaxAge:   int(h.StateTTL.Seconds()),
+		HttpOnly: true,
+		Secure:   r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https",
+		SameSite: http.SameSiteLaxMode,
+	})
+	w.Header().Set("Cache-Control", "no-store")
+	http.Redirect(w, r, authURL, http.StatusFound)
+}
@@ -0,0 +1,86 @@
+package oauth
+
+import (
+	"context"
+	"errors"
+	"github.com/SeiFlow-3P2/auth_service/internal/domain"
+	"github.com/SeiFlow-3P2/auth_service/pkg/oauth2/authOauth"
+	"log/slog"
+	"net/http"
+	"net/url"
+)
+
+// Auth is the part of auth service used by OAuth login handlers
+type Auth interface {
+	BeginOAuth(ctx context.Context, provider string) (authURL string, binding string, err error)
+	FinishOAuth(ctx context.Context, provider string, state string, code string, binding string) (loginCode string, mfaChallenge string, err error)
+}
+
+// bindingCookie keeps the browser that started the login, state is accepted only with it
+const (
+	bindingCookie     = "oauth_binding"
+	bindingCookiePath = "/callback/"
+)
+
+// Callback handles redirect from OAuth provider (GET /callback/{provider}).
+// The user is sent to FrontendURL with one of the query parameters:
+// code — one-time login code for ExchangeLoginCode, mfa_challenge — for VerifyMFA,
+// error — why login failed.
+type Callback struct {
+	Auth        Auth
+	FrontendURL string
+	Log         *slog.Logger
+}
+
+func (h *Callback) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	op := "OAuth_Callback: "
+	query := r.URL.Query()
+
+	// Пользователь отказался на странице провайдера
+	if reason := query.Get("error"); reason != "" {
+		h.redirect(w, r, "error", reason)
+		return
+	}
+	if query.Get("state") == "" || query.Get("code") == "" {
+		h.redirect(w, r, "error", "invalid_request")
+		return
+	}
+
+	var binding string
+	if cookie, err := r.Cookie(bindingCookie); err == nil {
+		binding = cookie.Value
+	}
+	// Cookie одноразовая, как и state
+	http.SetCookie(w, &http.Cookie{Name: bindingCookie, Path: bindingCookiePath, MaxAge: -1, HttpOnly: true})
+
+	loginCode, mfaChallenge, err := h.Auth.FinishOAuth(r.Context(), r.PathValue("provider"), query.Get("state"), query.Get("code"), binding)
+	switch {
+	case err == nil && mfaChallenge != "":
+		h.redirect(w, r, "mfa_challenge", mfaChallenge)
+	case err == nil:
+		h.redirect(w, r, "code", loginCode)
+	case errors.Is(err, domain.ErrInvalidToken):
+		h.redirect(w, r, "error", "invalid_state")
+	case errors.Is(err, authOauth.ErrEmailNotVerified):
+		h.redirect(w, r, "error", "email_not_verified")
+	case errors.Is(err, domain.ErrAccountExists):
+		h.redirect(w, r, "error", "account_exists")
+	default:
+		h.Log.Error(op, slog.String(op, err.Error()))
+		h.redirect(w, r, "error", "server_error")
+	}
+}
+
+func (h *Callback) redirect(w http.ResponseWriter, r *http.Request, key string, value string) {
+	target, err := url.Parse(h.FrontendURL)
+	if err != nil {
+		http.Error(w, "invalid frontend url", http.StatusInternalServerError)
+		return
+	}
+	query := target.Query()
+	query.Set(key, value)
+	target.RawQuery = query.Encode()
+
+	w.Header().Set("Cache-Control", "no-store")
+	http.Redirect(w, r, target.String(), http.StatusFound)
+}
@@ -0,0 +1,84 @@
+package oidc
+
+import (
+	"errors"
+	"github.com/SeiFlow-3P2/auth_service/internal/domain"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Authorize handles authorization request of the client (GET or POST /authorize).
+// The user is sent to the login page with request_id, the page answers the request
+// with ApproveAuthorization and sends the user back to the client.
+type Authorize struct {
+	Auth   Auth
+	Issuer string
+	Log    *slog.Logger
+}
+
+func (h *Authorize) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	op := "OIDC_Authorize: "
+
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		w.Header().Set("Allow", "GET, POST")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "invalid request", http.StatusBadRequest)
+		return
+	}
+	request := domain.AuthorizationRequest{
+		ClientID:            r.Form.Get("client_id"),
+		RedirectURI:         r.Form.Get("redirect_uri"),
+		Scopes:              strings.Fields(r.Form.Get("scope")),
+		State:               r.Form.Get("state"),
+		Nonce:               r.Form.Get("nonce"),
+		CodeChallenge:       r.Form.Get("code_challenge"),
+		ResponseType:        r.Form.Get("response_type"),
+		CodeChallengeMethod: r.Form.Get("code_challenge_method"),
+		Prompt:              r.Form.Get("prompt"),
+	}
+
+	loginURL, err := h.Auth.Authorize(r.Context(), request)
+	var oauthErr *domain.OAuthError
+	switch {
+	case err == nil:
+		w.Header().Set("Cache-Control", "no-store")
+		http.Redirect(w, r, loginURL, http.StatusFound)
+	// Клиент или redirect_uri не подтверждены, перенаправлять туда нельзя
+	case errors.Is(err, domain.ErrInvalidClient):
+		http.Error(w, "unknown client_id", http.StatusBadRequest)
+	case errors.Is(err, domain.ErrInvalidRedirect):
+		http.Error(w, "redirect_uri is not registered for the client", http.StatusBadRequest)
+	case errors.As(err, &oauthErr):
+		h.redirectError(w, r, request, oauthErr)
+	default:
+		h.Log.Error(op, slog.String(op, err.Error()))
+		http.Error(w, "server error", http.StatusInternalServerError)
+	}
+}
+
+// redirectError returns the user to the client with error (RFC 6749 4.1.2.1)
+func (h *Authorize) redirectError(w http.ResponseWriter, r *http.Request, request domain.AuthorizationRequest, oauthErr *domain.OAuthError) {
+	target, err := url.Parse(request.RedirectURI)
+	if err != nil {
+		http.Error(w, "invalid redirect_uri", http.StatusBadRequest)
+		return
+	}
+	query := target.Query()
+	query.Set("error", oauthErr.Code)
+	if oauthErr.Description != "" {
+		query.Set("error_description", oauthErr.Description)
+	}
+	if request.State != "" {
+		query.Set("state", request.State)
+	}
+	query.Set("iss", h.Issuer)
+	target.RawQuery = query.Encode()
+
+	w.Header().Set("Cache-Control", "no-store")
+	http.Redirect(w, r, target.String(), http.StatusFound)
+}
@@ -0,0 +1,62 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/SeiFlow-3P2/auth_service/internal/domain"
+	"github.com/SeiFlow-3P2/auth_service/pkg/authKeys"
+	"net/http"
+	"slices"
+)
+
+// Auth is the part of auth service used by OpenID Connect endpoints
+type Auth interface {
+	Authorize(ctx context.Context, request domain.AuthorizationRequest) (loginURL string, err error)
+	ExchangeAuthorizationCode(ctx context.Context, clientID string, clientSecret string, code string, redirectURI string, codeVerifier string) (tokens domain.OIDCTokens, err error)
+	OIDCUserInfo(ctx context.Context, accessToken string) (claims map[string]any, err error)
+	IssueClientToken(ctx context.Context, clientID string, clientSecret string, scopes []string) (tokens domain.OIDCTokens, err error)
+}
+
+// Discovery serves provider metadata (GET /.well-known/openid-configuration)
+type Discovery struct {
+	Issuer string
+	Keys   *authKeys.Keyring
+}
+
+func (h *Discovery) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// Алгоритмы берутся из ключей на момент запроса, набор меняется при ротации
+	var algs []string
+	for _, key := range h.Keys.Keys() {
+		if alg := key.Method.Alg(); !slices.Contains(algs, alg) {
+			algs = append(algs, alg)
+		}
+	}
+
+	w.Header().Set("Cache-Control", "public, max-age=3600")
+	writeJSON(w, http.StatusOK, map[string]any{
+		"issuer":                                h.Issuer,
+		"authorization_endpoint":                h.Issuer + "/authorize",
+		"token_endpoint":                        h.Issuer + "/token",
+		"userinfo_endpoint":                     h.Issuer + "/userinfo",
+		"jwks_uri":                              h.Issuer + "/.well-known/jwks.json",
+		"response_types_supported":              []string{"code"},
+		"response_modes_supported":              []string{"query"},
+		"grant_types_supported":                 []string{domain.GrantAuthorizationCode, domain.GrantClientCredentials},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": algs,
+		"scopes_supported":                      []string{domain.ScopeOpenID, domain.ScopeProfile, domain.ScopeEmail},
+		"token_endpoint_auth_methods_supported": []string{"client_secret_basic", "client_secret_post", "none"},
+		"code_challenge_methods_supported":      []string{"S256"},
+		"claims_supported": []string{
+			"iss", "sub", "aud", "exp", "iat", "auth_time", "nonce", "azp",
+			"name", "preferred_username", "picture", "updated_at", "email", "email_verified",
+		},
+		"authorization_response_iss_parameter_supported": true,
+	})
+}
+
+func writeJSON(w http.ResponseWriter, code int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,115 @@
+package oidc
+
+import (
+	"errors"
+	"github.com/SeiFlow-3P2/auth_service/internal/domain"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Token exchanges authorization codes for tokens and issues tokens of services
+// for client_credentials grant (POST /token)
+type Token struct {
+	Auth Auth
+	Log  *slog.Logger
+}
+
+func (h *Token) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	op := "OIDC_Token: "
+
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Pragma", "no-cache")
+	if err := r.ParseForm(); err != nil {
+		h.writeError(w, http.StatusBadRequest, &domain.OAuthError{Code: "invalid_request"})
+		return
+	}
+	clientID, clientSecret, basic, err := clientCredentials(r)
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, &domain.OAuthError{Code: "invalid_request", Description: err.Error()})
+		return
+	}
+
+	switch r.PostForm.Get("grant_type") {
+	case domain.GrantAuthorizationCode:
+		tokens, err := h.Auth.ExchangeAuthorizationCode(r.Context(), clientID, clientSecret,
+			r.PostForm.Get("code"), r.PostForm.Get("redirect_uri"), r.PostForm.Get("code_verifier"))
+		if err != nil {
+			h.fail(w, op, basic, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]any{
+			"access_token": tokens.AccessToken,
+			"token_type":   "Bearer",
+			"expires_in":   int64(tokens.ExpiresIn.Seconds()),
+			"id_token":     tokens.IDToken,
+			"scope":        strings.Join(tokens.Scopes, " "),
+		})
+	case domain.GrantClientCredentials:
+		tokens, err := h.Auth.IssueClientToken(r.Context(), clientID, clientSecret, strings.Fields(r.PostForm.Get("scope")))
+		if err != nil {
+			h.fail(w, op, basic, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]any{
+			"access_token": tokens.AccessToken,
+			"token_type":   "Bearer",
+			"expires_in":   int64(tokens.ExpiresIn.Seconds()),
+			"scope":        strings.Join(tokens.Scopes, " "),
+		})
+	case "":
+		h.writeError(w, http.StatusBadRequest, &domain.OAuthError{Code: "invalid_request", Description: "grant_type is required"})
+	default:
+		h.writeError(w, http.StatusBadRequest, &domain.OAuthError{Code: "unsupported_grant_type"})
+	}
+}
+
+// clientCredentials returns client id and secret from basic auth (client_secret_basic)
+// or from the form (client_secret_post, none). Using both at once is not allowed.
+func clientCredentials(r *http.Request) (clientID string, clientSecret string, basic bool, err error) {
+	user, password, basic := r.BasicAuth()
+	if !basic {
+		return r.PostForm.Get("client_id"), r.PostForm.Get("client_secret"), false, nil
+	}
+	if r.PostForm.Get("client_secret") != "" {
+		return "", "", true, errors.New("multiple client authentication methods")
+	}
+	// В basic auth id и секрет закодированы как в форме (RFC 6749 2.3.1)
+	if clientID, err = url.QueryUnescape(user); err != nil {
+		return "", "", true, err
+	}
+	if clientSecret, err = url.QueryUnescape(password); err != nil {
+		return "", "", true, err
+	}
+	if formID := r.PostForm.Get("client_id"); formID != "" && formID != clientID {
+		return "", "", true, errors.New("client_id does not match")
+	}
+	return clientID, clientSecret, true, nil
+}
+
+func (h *Token) fail(w http.ResponseWriter, op string, basic bool, err error) {
+	var oauthErr *domain.OAuthError
+	switch {
+	case errors.Is(err, domain.ErrInvalidClient):
+		if basic {
+			w.Header().Set("WWW-Authenticate", `Basic realm="token"`)
+		}
+		h.writeError(w, http.StatusUnauthorized, &domain.OAuthError{Code: "invalid_client"})
+	case errors.As(err, &oauthErr):
+		h.writeError(w, http.StatusBadRequest, oauthErr)
+	case errors.Is(err, domain.ErrOIDCDisabled):
+		h.writeError(w, http.StatusBadRequest, &domain.OAuthError{Code: "unsupported_grant_type"})
+	default:
+		h.Log.Error(op, slog.String(op, err.Error()))
+		h.writeError(w, http.StatusInternalServerError, &domain.OAuthError{Code: "server_error"})
+	}
+}
+
+func (h *Token) writeError(w http.ResponseWriter, code int, oauthErr *domain.OAuthError) {
+	body := map[string]string{"error": oauthErr.Code}
+	if oauthErr.Description != "" {
+		body["error_description"] = oauthErr.Description
+	}
+	writeJSON(w, code, body)
+}
@@ -0,0 +1,48 @@
+package oidc
+
+import (
+	"errors"
+	"github.com/SeiFlow-3P2/auth_service/internal/domain"
+	"log/slog"
+	"net/http"
+	"strings"
+)
+
+// UserInfo returns claims of the user to the client (GET or POST /userinfo with bearer token)
+type UserInfo struct {
+	Auth Auth
+	Log  *slog.Logger
+}
+
+func (h *UserInfo) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	op := "OIDC_UserInfo: "
+
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		w.Header().Set("Allow", "GET, POST")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Cache-Control", "no-store")
+
+	token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !found || token == "" {
+		w.Header().Set("WWW-Authenticate", `Bearer`)
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	claims, err := h.Auth.OIDCUserInfo(r.Context(), token)
+	switch {
+	case err == nil:
+		writeJSON(w, http.StatusOK, claims)
+	case errors.Is(err, domain.ErrUnauthenticated):
+		w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+		w.WriteHeader(http.StatusUnauthorized)
+	case errors.Is(err, domain.ErrForbidden):
+		w.Header().Set("WWW-Authenticate", `Bearer error="insufficient_scope", scope="openid"`)
+		w.WriteHeader(http.StatusForbidden)
+	default:
+		h.Log.Error(op, slog.String(op, err.Error()))
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
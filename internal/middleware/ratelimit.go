@@ -0,0 +1,280 @@
+package middleware
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/SeiFlow-3P2/auth_service/pkg/authRedis"
+	"github.com/SeiFlow-3P2/auth_service/pkg/utils/clientinfo"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// Rate limit keys
+const (
+	KeyIP     = "ip"
+	KeyUser   = "user"
+	KeyAPIKey = "api_key"
+)
+
+// RatePolicy is token bucket: Rate tokens per second, at most Burst at once
+type RatePolicy struct {
+	Key   string
+	Rate  float64
+	Burst int
+}
+
+// Limiter takes one token from the bucket of key
+type Limiter interface {
+	Allow(ctx context.Context, key string, policy RatePolicy) (allowed bool, retryAfter time.Duration, err error)
+}
+
+// RateLimitConfig configures rate limit interceptor
+type RateLimitConfig struct {
+	// Policies по полному имени метода, "*" — для остальных методов
+	Policies map[string]RatePolicy
+	// UserID returns user id of the access token, used by KeyUser policies
+	UserID func(accessToken string) (string, error)
+	// APIKey returns name of known API key, used by KeyAPIKey policies
+	APIKey func(apiKey string) (name string, ok bool)
+}
+
+// RateLimitInterceptor rejects requests over the policy of the method with
+// ResourceExhausted and retry-after header. Requests whose user or API key is
+// unknown are limited by client IP, see clientinfo.IP for how it is resolved.
+func RateLimitInterceptor(limiter Limiter, cfg RateLimitConfig, log *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		policy, ok := cfg.Policies[info.FullMethod]
+		if !ok {
+			policy, ok = cfg.Policies["*"]
+		}
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		key := info.FullMethod + ":" + requestKey(ctx, policy.Key, cfg)
+		allowed, retryAfter, err := limiter.Allow(ctx, key, policy)
+		if err != nil {
+			// Лимит не должен ронять сервис, пропускаем запрос
+			log.Error("rate limit", slog.String("method", info.FullMethod), slog.Any("err", err))
+			return handler(ctx, req)
+		}
+		if !allowed {
+			seconds := int64(math.Ceil(retryAfter.Seconds()))
+			_ = grpc.SetHeader(ctx, metadata.Pairs("retry-after", strconv.FormatInt(seconds, 10)))
+
+			st, _ := status.New(codes.ResourceExhausted, "rate limit exceeded").
+				WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(retryAfter)})
+			return nil, st.Err()
+		}
+		return handler(ctx, req)
+	}
+}
+
+// requestKey returns "<kind>:<value>" identifying the caller
+func requestKey(ctx context.Context, kind string, cfg RateLimitConfig) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	switch kind {
+	case KeyAPIKey:
+		// Случайные ключи не должны давать новые корзины, поэтому только известные
+		if values := md.Get("x-api-key"); len(values) > 0 && values[0] != "" && cfg.APIKey != nil {
+			if name, ok := cfg.APIKey(values[0]); ok {
+				return "api_key:" + name
+			}
+		}
+	case KeyUser:
+		if values := md.Get("authorization"); len(values) > 0 && cfg.UserID != nil {
+			if token, found := strings.CutPrefix(values[0], "Bearer "); found {
+				if id, err := cfg.UserID(token); err == nil && id != "" {
+					return "user:" + id
+				}
+			}
+		}
+	}
+	return "ip:" + clientinfo.IP(ctx)
+}
+
+// APIKeys maps SHA-256 of API keys to their names
+type APIKeys map[string]string
+
+// ParseAPIKeys parses keys like "partner=secret;billing=other-secret"
+func ParseAPIKeys(value string) (APIKeys, error) {
+	keys := make(APIKeys)
+	for _, item := range strings.Split(value, ";") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		name, key, found := strings.Cut(item, "=")
+		name, key = strings.TrimSpace(name), strings.TrimSpace(key)
+		if !found || name == "" || key == "" {
+			return nil, fmt.Errorf("invalid api key %q, expected <name>=<key>", name)
+		}
+		keys[apiKeyHash(key)] = name
+	}
+	return keys, nil
+}
+
+// Name returns name of the API key, ok is false for unknown keys
+func (k APIKeys) Name(apiKey string) (name string, ok bool) {
+	name, ok = k[apiKeyHash(apiKey)]
+	return name, ok
+}
+
+func apiKeyHash(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// ParseRatePolicies parses policies like
+// "/auth_v1.AuthService/Login=ip:10/1m:5;*=ip:100/1s".
+// Each policy is <key>:<count>/<period>[:<burst>], burst defaults to count.
+func ParseRatePolicies(value string) (map[string]RatePolicy, error) {
+	policies := make(map[string]RatePolicy)
+	for _, item := range strings.Split(value, ";") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		method, spec, found := strings.Cut(item, "=")
+		if !found {
+			return nil, fmt.Errorf("invalid rate policy %q", item)
+		}
+		policy, err := parseRatePolicy(spec)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rate policy %q: %w", item, err)
+		}
+		policies[strings.TrimSpace(method)] = policy
+	}
+	return policies, nil
+}
+
+func parseRatePolicy(spec string) (RatePolicy, error) {
+	parts := strings.Split(strings.TrimSpace(spec), ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return RatePolicy{}, fmt.Errorf("expected <key>:<count>/<period>[:<burst>]")
+	}
+
+	key := parts[0]
+	if key != KeyIP && key != KeyUser && key != KeyAPIKey {
+		return RatePolicy{}, fmt.Errorf("unknown key %q", key)
+	}
+
+	countStr, periodStr, found := strings.Cut(parts[1], "/")
+	if !found {
+		return RatePolicy{}, fmt.Errorf("expected <count>/<period>")
+	}
+	count, err := strconv.Atoi(countStr)
+	if err != nil || count <= 0 {
+		return RatePolicy{}, fmt.Errorf("invalid count %q", countStr)
+	}
+	period, err := time.ParseDuration(periodStr)
+	if err != nil || period <= 0 {
+		return RatePolicy{}, fmt.Errorf("invalid period %q", periodStr)
+	}
+
+	burst := count
+	if len(parts) == 3 {
+		burst, err = strconv.Atoi(parts[2])
+		if err != nil || burst <= 0 {
+			return RatePolicy{}, fmt.Errorf("invalid burst %q", parts[2])
+		}
+	}
+	return RatePolicy{Key: key, Rate: float64(count) / period.Seconds(), Burst: burst}, nil
+}
+
+// MemoryLimiter keeps token buckets in process memory. Подходит для одного экземпляра сервиса.
+type MemoryLimiter struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+type bucket struct {
+	tokens float64
+	ts     time.Time
+	// full время, после которого бакет снова полон и его можно удалить
+	full time.Time
+}
+
+// NewMemoryLimiter returns empty in-memory limiter
+func NewMemoryLimiter() *MemoryLimiter {
+	return &MemoryLimiter{buckets: make(map[string]*bucket), now: time.Now}
+}
+
+// Allow takes token from the bucket of key
+func (l *MemoryLimiter) Allow(ctx context.Context, key string, policy RatePolicy) (bool, time.Duration, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	burst := float64(policy.Burst)
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: burst, ts: now}
+		l.buckets[key] = b
+	}
+	b.tokens = math.Min(burst, b.tokens+now.Sub(b.ts).Seconds()*policy.Rate)
+	b.ts = now
+
+	allowed := false
+	var wait time.Duration
+	if b.tokens >= 1 {
+		b.tokens--
+		allowed = true
+	} else {
+		wait = time.Duration((1 - b.tokens) / policy.Rate * float64(time.Second))
+	}
+	b.full = now.Add(time.Duration((burst - b.tokens) / policy.Rate * float64(time.Second)))
+	return allowed, wait, nil
+}
+
+// sweep removes full buckets once a minute so memory doesn't grow with unique keys
+func (l *MemoryLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < time.Minute {
+		return
+	}
+	l.lastSweep = now
+	for key, b := range l.buckets {
+		if now.After(b.full) {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// RedisLimiter keeps token buckets in Redis, so the limit is shared by all instances.
+// If Redis is unavailable it falls back to in-memory buckets.
+type RedisLimiter struct {
+	Casher   *authRedis.Casher
+	Fallback *MemoryLimiter
+	Log      *slog.Logger
+}
+
+// Allow takes token from the bucket of key
+func (l *RedisLimiter) Allow(ctx context.Context, key string, policy RatePolicy) (bool, time.Duration, error) {
+	allowed, wait, err := l.Casher.TakeToken(ctx, key, policy.Rate, policy.Burst)
+	if err == nil {
+		return allowed, wait, nil
+	}
+	if l.Fallback == nil {
+		return false, 0, err
+	}
+	l.Log.Warn("rate limit redis unavailable, using memory", slog.Any("err", err))
+	return l.Fallback.Allow(ctx, key, policy)
+}
@@ -2,158 +2,408 @@ package service
 
 import (
 	"context"
-	"encoding/base64"
 	"errors"
 	"github.com/SeiFlow-3P2/auth_service/internal/domain"
 	"github.com/SeiFlow-3P2/auth_service/pkg/authJWT"
+	"github.com/SeiFlow-3P2/auth_service/pkg/authRedis"
+	"github.com/SeiFlow-3P2/auth_service/pkg/utils/clientinfo"
 	verfic "github.com/SeiFlow-3P2/auth_service/pkg/utils/verifications"
-	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 )
 
 type Auth struct {
 	*domain.App
-}
 
-func (a *Auth) SingUpByOauth(ctx context.Context, provider string, oauthToken string, telegramID string) (userID uuid.UUID, accessToken string, refreshToken string, message string, err error) {
-	//TODO implement me
-	panic("implement me")
+	dummyHashOnce sync.Once
+	dummyHash     []byte
 }
 
-func (a *Auth) LoginByEmail(ctx context.Context, email string, password []byte) (userID uuid.UUID, accessToken string, refreshToken string, message string, err error) {
-
-	refreshToken, passwordHash, err := a.Casher.UserSession(ctx, email)
-	if refreshToken == "" {
-		user, err := a.AuthDB.GetUserByEmail(email)
+// dummyPasswordHash returns hash that is checked when there is no password to check,
+// so unknown email takes as long as a wrong password
+func (a *Auth) dummyPasswordHash() []byte {
+	a.dummyHashOnce.Do(func() {
+		hash, err := a.Hasher.Hash([]byte("dummy password"))
 		if err != nil {
-			return uuid.Nil, "", "", "", err
-		}
-		if user == nil {
-			return uuid.Nil, "", "", "", errors.New("user not found")
+			a.Logger.Error("Auth_Service_dummyPasswordHash: ", slog.String("err", err.Error()))
+			return
 		}
-		if string(user.PasswordHash) != string(password) {
-			return uuid.Nil, "", "", "", errors.New("invalid password")
-		}
-		tokens, err := authJWT.CreateTokenPair(ctx, *user, a.Settings)
+		a.dummyHash = hash
+	})
+	return a.dummyHash
+}
+
+func (a *Auth) SingUpByOauth(ctx context.Context, provider string, oauthToken string, telegramID string) (userID uuid.UUID, accessToken string, refreshToken string, message string, err error) {
+	var tgID uint
+	if telegramID != "" {
+		id, err := strconv.ParseUint(telegramID, 10, 64)
 		if err != nil {
-			return uuid.Nil, "", "", "", err
+			return uuid.Nil, "", "", "Неверный telegram id", err
 		}
+		tgID = uint(id)
+	}
 
-		err = a.Casher.SetSession(ctx, user.Email, tokens.RefreshToken, user.PasswordHash)
-
-		return user.ID, tokens.AccessToken, tokens.RefreshToken, "", err
+	info, err := a.oauthUserInfo(ctx, provider, oauthToken)
+	if err != nil {
+		return uuid.Nil, "", "", "", err
 	}
 
+	user, err := a.oauthUser(ctx, info, tgID)
 	if err != nil {
 		return uuid.Nil, "", "", "", err
 	}
 
-	if string(passwordHash) != string(password) {
-		return uuid.Nil, "", "", "", errors.New("invalid password")
+	tokens, err := a.loginTokens(ctx, user)
+	if err != nil {
+		return uuid.Nil, "", "", "", err
 	}
+	return user.ID, tokens.AccessToken, tokens.RefreshToken, "", nil
+}
 
-	user, err := a.AuthDB.GetUserByEmail(email)
+func (a *Auth) LoginByEmail(ctx context.Context, email string, password []byte) (userID uuid.UUID, accessToken string, refreshToken string, message string, err error) {
+	op := "Auth_Service_LoginByEmail: "
 
+	attempts, err := a.reserveLogin(ctx, email)
 	if err != nil {
+		return uuid.Nil, "", "", "", err
+	}
 
+	user, err := a.AuthDB.GetUserByEmail(email)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// Хеш считается и для неизвестного email, иначе по времени ответа видно, что аккаунта нет
+			_, _ = a.Hasher.Verify(password, a.dummyPasswordHash())
+			a.loginFailed(ctx, nil, attempts)
+		} else {
+			a.forgetAttempts(ctx, attempts)
+		}
 		return uuid.Nil, "", "", "", err
 	}
+	if user == nil {
+		a.forgetAttempts(ctx, attempts)
+		return uuid.Nil, "", "", "", errors.New("user not found")
+	}
 
-	tokens, err := authJWT.CreateTokenPair(ctx, *user, a.Settings)
+	encoded := user.PasswordHash
+	if len(encoded) == 0 {
+		// У аккаунта без пароля (вход через OAuth) проверка не должна быть быстрее
+		encoded = a.dummyPasswordHash()
+	}
+	needsRehash, err := a.Hasher.Verify(password, encoded)
+	if err != nil || len(user.PasswordHash) == 0 {
+		a.loginFailed(ctx, user, attempts)
+		return uuid.Nil, "", "", "", errors.New("invalid password")
+	}
+	a.loginSucceeded(ctx, email, attempts)
+	if a.Settings.RequireVerifiedEmail && !user.EmailVerified() {
+		return uuid.Nil, "", "", "", domain.ErrEmailNotVerified
+	}
+	if needsRehash {
+		// Пароль верный, но хеш устарел — перехешируем с текущими параметрами
+		passwordHash, err := a.Hasher.Hash(password)
+		if err != nil {
+			a.Logger.Error(op, slog.String(op, err.Error()))
+		} else if err = a.AuthDB.ChangePassword(user.ID, passwordHash); err != nil {
+			a.Logger.Error(op, slog.String(op, err.Error()))
+		} else {
+			user.PasswordHash = passwordHash
+		}
+	}
 
+	tokens, err := a.loginTokens(ctx, user)
 	if err != nil {
 		return uuid.Nil, "", "", "", err
 	}
-	return user.ID, tokens.AccessToken, tokens.RefreshToken, "", err
 
+	return user.ID, tokens.AccessToken, tokens.RefreshToken, "", nil
 }
 
 func (a *Auth) LoginByOauth(ctx context.Context, provider string, oauthToken string) (userID uuid.UUID, accessToken string, refreshToken string, message string, err error) {
-	//TODO implement me
-	panic("implement me")
+	info, err := a.oauthUserInfo(ctx, provider, oauthToken)
+	if err != nil {
+		return uuid.Nil, "", "", "", err
+	}
+
+	user, err := a.oauthUser(ctx, info, 0)
+	if err != nil {
+		return uuid.Nil, "", "", "", err
+	}
+
+	tokens, err := a.loginTokens(ctx, user)
+	if err != nil {
+		return uuid.Nil, "", "", "", err
+	}
+	return user.ID, tokens.AccessToken, tokens.RefreshToken, "", nil
 }
 
-func (a *Auth) RefreshToken(ctx context.Context, RefreshToken string) (accessToken string, refreshToken string, err error) {
-	op := "Auth_Service_RefreshToken: "
-	refToken, err := jwt.Parse(RefreshToken,
-		func(token *jwt.Token) (interface{}, error) {
-			secret, err := base64.StdEncoding.DecodeString(a.Settings.Secret)
-			if err != nil {
-				a.Logger.Info(op + err.Error())
-				return nil, err
-			}
-			return secret, nil
-		})
+// oauthUserInfo exchanges authorization code and fetches user profile.
+// Only codes are accepted: access token of the provider could be issued to another application.
+func (a *Auth) oauthUserInfo(ctx context.Context, provider string, oauthToken string) (*domain.UserInfo, error) {
+	op := "Auth_Service_oauthUserInfo: "
 
+	p, ok := a.OauthProviders[provider]
+	if !ok {
+		return nil, errors.New("unknown oauth provider")
+	}
+	if oauthToken == "" {
+		return nil, errors.New("empty oauth token")
+	}
+
+	token, err := p.Exchange(ctx, oauthToken)
+	if err != nil {
+		a.Logger.Info(op, slog.String(op, err.Error()))
+		return nil, domain.ErrInvalidToken
+	}
+
+	info, err := p.UserInfo(ctx, token)
 	if err != nil {
 		a.Logger.Error(op, slog.String(op, err.Error()))
-		return "", "", err
+		return nil, err
+	}
+	return info, nil
+}
 
+// oauthUser finds user by linked provider account, then by provider email, or creates new one.
+// Провайдеры отдают только подтверждённые email, поэтому email пользователя считается подтверждённым.
+func (a *Auth) oauthUser(ctx context.Context, info *domain.UserInfo, telegramID uint) (*domain.User, error) {
+	identity, err := a.AuthDB.GetIdentity(info.Provider, identitySubject(info))
+	if err == nil {
+		return a.AuthDB.GetUser(identity.UserID)
 	}
-	claims, ok := refToken.Claims.(jwt.MapClaims)
-	if ok != true {
-		a.Logger.Error(op, slog.String(op, "cant get claims"))
-		return "", "", errors.New("invalid token")
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
 	}
 
-	expired := time.Unix(int64(claims["exp"].(float64)), 0)
-	if time.Now().After(expired) {
-		return "", "", errors.New("token expired")
+	user, err := a.emailUser(info, telegramID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := a.linkIdentity(ctx, user, info.Provider, identitySubject(info), info.Email, ""); err != nil {
+		return nil, err
 	}
+	return user, nil
+}
 
-	email := claims["email"].(string)
+// emailUser finds user by verified provider email or creates new one. Accounts with
+// unconfirmed email are not linked: whoever registered it may not own the address.
+func (a *Auth) emailUser(info *domain.UserInfo, telegramID uint) (*domain.User, error) {
+	if !mailable(info.Email) {
+		return nil, domain.ErrAccountExists
+	}
+	user, err := a.AuthDB.GetUserByEmail(info.Email)
+	if err == nil {
+		if !user.EmailVerified() {
+			return nil, domain.ErrAccountExists
+		}
+		return user, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
 
-	refreshToken, _, err = a.Casher.UserSession(ctx, email)
+	username := info.Name
+	if username == "" {
+		username = strings.Split(info.Email, "@")[0]
+	}
+	err = a.AuthDB.CreateUser(username, info.Email, info.AvatarURL, telegramID, nil)
+	if err != nil {
+		// Имя уже может быть занято, пробуем ещё раз с идентификатором провайдера
+		username = username + "_" + info.Provider + info.ID
+		if err := a.AuthDB.CreateUser(username, info.Email, info.AvatarURL, telegramID, nil); err != nil {
+			return nil, err
+		}
+	}
+	user, err = a.AuthDB.GetUserByEmail(info.Email)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if err := a.AuthDB.SetEmailVerified(user.ID, now); err != nil {
+		return nil, err
+	}
+	user.VerifiedAt = &now
+	return user, nil
+}
 
+// issueTokens starts new session for the user and stores it in casher
+func (a *Auth) issueTokens(ctx context.Context, user *domain.User) (domain.Tokens, error) {
+	sessionID, err := uuid.NewRandom()
 	if err != nil {
-		return "", "", err
+		return domain.Tokens{}, err
 	}
-	if refreshToken == "" {
-		return "", "", errors.New("invalid token")
+
+	tokens, err := authJWT.CreateTokenPair(ctx, *user, sessionID, a.Settings)
+	if err != nil {
+		return domain.Tokens{}, err
 	}
-	if refreshToken != RefreshToken {
-		return "", "", errors.New("invalid token")
+
+	now := time.Now()
+	err = a.Casher.SetSession(ctx, authRedis.Session{
+		ID:         sessionID.String(),
+		UserID:     user.ID.String(),
+		Email:      user.Email,
+		RefreshID:  tokens.RefreshTokenID,
+		UserAgent:  clientinfo.UserAgent(ctx),
+		IP:         clientinfo.IP(ctx),
+		CreatedAt:  now,
+		LastUsedAt: now,
+	})
+	if err != nil {
+		return domain.Tokens{}, err
 	}
+	return tokens, nil
+}
 
-	user, err := a.AuthDB.GetUserByEmail(email)
+// RefreshToken rotates refresh token of the session. Presenting a token of the session
+// that was already rotated means it leaked, so the whole session is revoked.
+func (a *Auth) RefreshToken(ctx context.Context, RefreshToken string) (accessToken string, refreshToken string, err error) {
+	op := "Auth_Service_RefreshToken: "
+	claims, err := authJWT.ParseToken(RefreshToken, authJWT.TokenTypeRefresh, a.Settings)
+	if err != nil {
+		a.Logger.Info(op, slog.String(op, err.Error()))
+		return "", "", domain.ErrInvalidToken
+	}
+
+	sessionID, err := uuid.Parse(claims.SessionID)
+	if err != nil {
+		return "", "", domain.ErrInvalidToken
+	}
+	refreshID := claims.ID
+	if refreshID == "" {
+		return "", "", domain.ErrInvalidToken
+	}
 
+	session, err := a.Casher.GetSession(ctx, sessionID.String())
+	if err != nil {
+		if errors.Is(err, authRedis.ErrSessionNotFound) {
+			return "", "", domain.ErrInvalidToken
+		}
+		return "", "", err
+	}
+	if session.RefreshID != refreshID {
+		a.revokeReusedSession(ctx, session, refreshID)
+		return "", "", domain.ErrTokenReused
+	}
+
+	// Email мог смениться, поэтому пользователя ищем по id
+	userID, err := uuid.Parse(session.UserID)
+	if err != nil {
+		return "", "", domain.ErrInvalidToken
+	}
+	user, err := a.AuthDB.GetUser(userID)
 	if err != nil {
 		return "", "", err
 	}
-	tokens, err := authJWT.CreateTokenPair(ctx, *user, a.Settings)
+	tokens, err := authJWT.CreateTokenPair(ctx, *user, sessionID, a.Settings)
 	if err != nil {
 		a.Logger.Info(err.Error())
 		return "", "", err
 	}
-	err = a.Casher.BlockSession(ctx, user.Email)
-	err = a.Casher.SetSession(ctx, user.Email, tokens.RefreshToken, user.PasswordHash)
+
+	session.RefreshID = tokens.RefreshTokenID
+	session.LastUsedAt = time.Now()
+	session.IP = clientinfo.IP(ctx)
+	session.UserAgent = clientinfo.UserAgent(ctx)
+	rotated, err := a.Casher.RotateSession(ctx, *session, refreshID)
+	if err != nil {
+		return "", "", err
+	}
+	if !rotated {
+		// Тот же токен успели использовать параллельно
+		a.revokeReusedSession(ctx, session, refreshID)
+		return "", "", domain.ErrTokenReused
+	}
 
 	return tokens.AccessToken, tokens.RefreshToken, nil
 
 }
 
-func (a *Auth) Logout(ctx context.Context, userID uuid.UUID) (err error) {
-	user, err := a.AuthDB.GetUser(userID)
+// revokeReusedSession revokes the token family and records security event
+func (a *Auth) revokeReusedSession(ctx context.Context, session *authRedis.Session, refreshID string) {
+	op := "Auth_Service_revokeReusedSession: "
+
+	a.Logger.Warn(op+"refresh token reuse detected",
+		slog.String("user_id", session.UserID),
+		slog.String("session_id", session.ID),
+		slog.String("ip", clientinfo.IP(ctx)),
+	)
+
+	if err := a.Casher.BlockSession(ctx, session.UserID, session.ID); err != nil {
+		a.Logger.Error(op, slog.String(op, err.Error()))
+	}
 
+	userID, err := uuid.Parse(session.UserID)
 	if err != nil {
-		return err
+		return
 	}
-	err = a.Casher.BlockSession(ctx, user.Email)
+	a.securityEvent(ctx, userID, domain.EventRefreshTokenReuse, session.ID, "reused refresh token jti "+refreshID)
+}
 
-	return err
+// securityEvent records security event, failure to save it doesn't fail the request
+func (a *Auth) securityEvent(ctx context.Context, userID uuid.UUID, eventType string, sessionID string, details string) {
+	op := "Auth_Service_securityEvent: "
+
+	err := a.AuthDB.CreateSecurityEvent(&domain.SecurityEvent{
+		UserID:    userID,
+		Type:      eventType,
+		SessionID: sessionID,
+		IP:        clientinfo.IP(ctx),
+		UserAgent: clientinfo.UserAgent(ctx),
+		Details:   details,
+	})
+	if err != nil {
+		a.Logger.Error(op, slog.String(op, err.Error()))
+	}
+}
+
+// Logout ends the session of the access token. Other devices stay logged in,
+// they are revoked by RevokeSession and RevokeAllSessions.
+func (a *Auth) Logout(ctx context.Context, accessToken string) (err error) {
+	session, err := a.authenticate(ctx, accessToken)
+	if err != nil {
+		return err
+	}
+	return a.Casher.BlockSession(ctx, session.UserID, session.ID)
 }
 
 func (a *Auth) SingUpByEmail(ctx context.Context, name string, email string, password []byte, telegramID uint) (userID uuid.UUID, accessToken string, refreshToken string, message string, err error) {
+	op := "Auth_Service_SingUpByEmail: "
+
 	validateEmail, err := verfic.VerifyEmail(email)
 	if err != nil || !validateEmail {
 		return uuid.Nil, "", "", "Неверный формат email", err
 	}
-	err = a.AuthDB.CreateUser(name, email, "", telegramID, password)
+	// Адреса-заглушки принадлежат аккаунтам Telegram
+	if !mailable(email) {
+		return uuid.Nil, "", "", "Неверный формат email", errors.New("invalid email")
+	}
+	passwordHash, err := a.Hasher.Hash(password)
+	if err != nil {
+		return uuid.Nil, "", "", "", err
+	}
+	err = a.AuthDB.CreateUser(name, email, "", telegramID, passwordHash)
+	if err != nil {
+		return uuid.Nil, "", "", "", err
+	}
+
+	user, err := a.AuthDB.GetUserByEmail(email)
 	if err != nil {
 		return uuid.Nil, "", "", "", err
 	}
+	// Пользователь уже создан, письмо можно запросить повторно через ResendVerification
+	if err := a.sendVerification(ctx, user); err != nil {
+		a.Logger.Error(op, slog.String(op, err.Error()))
+	}
+	if a.Settings.RequireVerifiedEmail {
+		return user.ID, "", "", "Подтвердите email по ссылке из письма", nil
+	}
+
 	userID, accessToken, refreshToken, message, err = a.LoginByEmail(ctx, email, password)
 	if err != nil {
 		return uuid.Nil, "", "", "", err
@@ -161,7 +411,48 @@ func (a *Auth) SingUpByEmail(ctx context.Context, name string, email string, pas
 	return userID, accessToken, refreshToken, message, nil
 }
 
-func (a *Auth) UserInfo(ctx context.Context, userID uuid.UUID) (id string, telegramID uint, username string, email string, photoUrl string, createdAt string, updatedAt string, err error) {
+// authorizeUserLookup lets the user read his own profile, admins and services with users scope any profile
+func (a *Auth) authorizeUserLookup(ctx context.Context, accessToken string, userID uuid.UUID) error {
+	claims, err := authJWT.ParseToken(accessToken, authJWT.TokenTypeAccess, a.Settings)
+	if err != nil {
+		return domain.ErrUnauthenticated
+	}
+	if claims.IsService() {
+		caller, err := a.introspectService(claims)
+		if err != nil {
+			return err
+		}
+		if !caller.Active {
+			return domain.ErrUnauthenticated
+		}
+		if !slices.Contains(caller.Scopes, domain.ScopeUsers) {
+			return domain.ErrForbidden
+		}
+		return nil
+	}
+
+	session, err := a.authenticate(ctx, accessToken)
+	if err != nil {
+		return err
+	}
+	if session.UserID == userID.String() {
+		return nil
+	}
+	user, err := a.sessionUser(session)
+	if err != nil {
+		return err
+	}
+	if !user.IsAdmin {
+		return domain.ErrForbidden
+	}
+	return nil
+}
+
+func (a *Auth) UserInfo(ctx context.Context, accessToken string, userID uuid.UUID) (id string, telegramID uint, username string, email string, photoUrl string, createdAt string, updatedAt string, err error) {
+	// Проверка до обращения к базе, чтобы по ответу нельзя было узнать, есть ли такой пользователь
+	if err := a.authorizeUserLookup(ctx, accessToken, userID); err != nil {
+		return "", 0, "", "", "", "", "", err
+	}
 	user, err := a.AuthDB.GetUser(userID)
 	if err != nil {
 		return "", 0, "", "", "", "", "", err
@@ -0,0 +1,242 @@
+package service
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"github.com/SeiFlow-3P2/auth_service/internal/domain"
+	"github.com/SeiFlow-3P2/auth_service/pkg/authJWT"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"log/slog"
+	"net"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+// supportedScopes скоупы, которые может получить зарегистрированное приложение
+var supportedScopes = []string{domain.ScopeOpenID, domain.ScopeProfile, domain.ScopeEmail}
+
+// checkRedirectURI accepts absolute uris without fragment. Plain http is allowed only
+// for loopback addresses of native apps, custom schemes (com.example.app:/cb) are allowed too.
+func checkRedirectURI(uri string) error {
+	parsed, err := url.Parse(uri)
+	if err != nil || !parsed.IsAbs() || parsed.Fragment != "" {
+		return fmt.Errorf("%w: invalid redirect uri %q", domain.ErrClientMetadata, uri)
+	}
+	if parsed.Scheme == "http" {
+		host := parsed.Hostname()
+		if ip := net.ParseIP(host); host != "localhost" && (ip == nil || !ip.IsLoopback()) {
+			return fmt.Errorf("%w: redirect uri %q must use https", domain.ErrClientMetadata, uri)
+		}
+	}
+	return nil
+}
+
+// maxServiceTokenTTL токены сервисов не отзываются до истечения, поэтому живут недолго
+const maxServiceTokenTTL = 24 * time.Hour
+
+// validScope checks scope-token syntax (RFC 6749 3.3)
+func validScope(scope string) bool {
+	if scope == "" {
+		return false
+	}
+	for _, c := range scope {
+		if c < 0x21 || c > 0x7e || c == '"' || c == '\\' {
+			return false
+		}
+	}
+	return true
+}
+
+// checkAppRegistration validates application which logs users in with authorization code
+func checkAppRegistration(registration *domain.ClientRegistration) error {
+	if len(registration.RedirectURIs) == 0 {
+		return fmt.Errorf("%w: no redirect uris", domain.ErrClientMetadata)
+	}
+	for _, uri := range registration.RedirectURIs {
+		if err := checkRedirectURI(uri); err != nil {
+			return err
+		}
+	}
+	if len(registration.Scopes) == 0 {
+		registration.Scopes = supportedScopes
+	}
+	for _, scope := range registration.Scopes {
+		if !slices.Contains(supportedScopes, scope) {
+			return fmt.Errorf("%w: unsupported scope %q", domain.ErrClientMetadata, scope)
+		}
+	}
+	if registration.TokenTTL != 0 {
+		return fmt.Errorf("%w: token ttl is set only for client_credentials", domain.ErrClientMetadata)
+	}
+	return nil
+}
+
+// checkServiceRegistration validates backend service which gets tokens with client_credentials.
+// Scopes of services are API scopes, OpenID Connect scopes describe users and are not allowed.
+func checkServiceRegistration(registration *domain.ClientRegistration) error {
+	if len(registration.GrantTypes) > 1 {
+		return fmt.Errorf("%w: client_credentials can't be combined with other grants", domain.ErrClientMetadata)
+	}
+	if registration.Public {
+		return fmt.Errorf("%w: service client must have a secret", domain.ErrClientMetadata)
+	}
+	if len(registration.RedirectURIs) > 0 {
+		return fmt.Errorf("%w: service client has no redirect uris", domain.ErrClientMetadata)
+	}
+	if len(registration.Scopes) == 0 {
+		return fmt.Errorf("%w: no scopes", domain.ErrClientMetadata)
+	}
+	for _, scope := range registration.Scopes {
+		if !validScope(scope) || slices.Contains(supportedScopes, scope) {
+			return fmt.Errorf("%w: invalid scope %q", domain.ErrClientMetadata, scope)
+		}
+	}
+	if registration.TokenTTL < 0 || registration.TokenTTL > maxServiceTokenTTL {
+		return fmt.Errorf("%w: token ttl must be up to %s", domain.ErrClientMetadata, maxServiceTokenTTL)
+	}
+	return nil
+}
+
+// RegisterOAuthClient registers application which logs users in through this service or backend
+// service with client_credentials grant, admin only. Secret is returned only here, public clients
+// get no secret and have to use PKCE.
+func (a *Auth) RegisterOAuthClient(ctx context.Context, accessToken string, registration domain.ClientRegistration) (client *domain.OAuthClient, secret string, err error) {
+	op := "Auth_Service_RegisterOAuthClient: "
+
+	if _, err := a.requireAdmin(ctx, accessToken); err != nil {
+		return nil, "", err
+	}
+	if registration.Name == "" {
+		return nil, "", fmt.Errorf("%w: empty client name", domain.ErrClientMetadata)
+	}
+	if len(registration.GrantTypes) == 0 {
+		registration.GrantTypes = []string{domain.GrantAuthorizationCode}
+	}
+	for _, grantType := range registration.GrantTypes {
+		if grantType != domain.GrantAuthorizationCode && grantType != domain.GrantClientCredentials {
+			return nil, "", fmt.Errorf("%w: unsupported grant type %q", domain.ErrClientMetadata, grantType)
+		}
+	}
+	if slices.Contains(registration.GrantTypes, domain.GrantClientCredentials) {
+		err = checkServiceRegistration(&registration)
+	} else {
+		err = checkAppRegistration(&registration)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	client = &domain.OAuthClient{
+		ClientID:     uuid.NewString(),
+		Name:         registration.Name,
+		RedirectURIs: strings.Join(registration.RedirectURIs, " "),
+		Scopes:       strings.Join(registration.Scopes, " "),
+		SkipConsent:  registration.SkipConsent,
+		GrantTypes:   strings.Join(registration.GrantTypes, " "),
+		TokenTTL:     registration.TokenTTL,
+	}
+	if !registration.Public {
+		secret, err = randomToken()
+		if err != nil {
+			return nil, "", err
+		}
+		client.SecretHash = hashToken(secret)
+	}
+	if err := a.AuthDB.CreateOAuthClient(client); err != nil {
+		a.Logger.Error(op, slog.String(op, err.Error()))
+		return nil, "", err
+	}
+	return client, secret, nil
+}
+
+// DeleteOAuthClient removes application, tokens already issued to it live until expiration. Admin only.
+func (a *Auth) DeleteOAuthClient(ctx context.Context, accessToken string, clientID string) (err error) {
+	op := "Auth_Service_DeleteOAuthClient: "
+
+	if _, err := a.requireAdmin(ctx, accessToken); err != nil {
+		return err
+	}
+	if err := a.AuthDB.DeleteOAuthClient(clientID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domain.ErrInvalidClient
+		}
+		a.Logger.Error(op, slog.String(op, err.Error()))
+		return err
+	}
+	return nil
+}
+
+// oauthClient returns registered application, unknown client is ErrInvalidClient
+func (a *Auth) oauthClient(clientID string) (*domain.OAuthClient, error) {
+	if clientID == "" {
+		return nil, domain.ErrInvalidClient
+	}
+	client, err := a.AuthDB.GetOAuthClient(clientID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrInvalidClient
+		}
+		return nil, err
+	}
+	return client, nil
+}
+
+// authenticateClient checks client credentials presented to the token endpoint.
+// Public clients authenticate only with client id.
+func (a *Auth) authenticateClient(clientID string, clientSecret string) (*domain.OAuthClient, error) {
+	client, err := a.oauthClient(clientID)
+	if err != nil {
+		return nil, err
+	}
+	if client.Public() {
+		if clientSecret != "" {
+			return nil, domain.ErrInvalidClient
+		}
+		return client, nil
+	}
+	if clientSecret == "" || subtle.ConstantTimeCompare([]byte(hashToken(clientSecret)), []byte(client.SecretHash)) != 1 {
+		return nil, domain.ErrInvalidClient
+	}
+	return client, nil
+}
+
+// IssueClientToken issues access token of the backend service for client_credentials grant.
+// Empty scopes request all scopes allowed to the client.
+func (a *Auth) IssueClientToken(ctx context.Context, clientID string, clientSecret string, scopes []string) (tokens domain.OIDCTokens, err error) {
+	op := "Auth_Service_IssueClientToken: "
+
+	client, err := a.authenticateClient(clientID, clientSecret)
+	if err != nil {
+		return domain.OIDCTokens{}, err
+	}
+	if client.Public() || !client.AllowsGrant(domain.GrantClientCredentials) {
+		return domain.OIDCTokens{}, &domain.OAuthError{Code: "unauthorized_client", Description: "client_credentials grant is not allowed"}
+	}
+
+	allowed := client.AllowedScopes()
+	if len(scopes) == 0 {
+		scopes = allowed
+	}
+	scopes = slices.Compact(slices.Sorted(slices.Values(scopes)))
+	for _, scope := range scopes {
+		if !slices.Contains(allowed, scope) {
+			return domain.OIDCTokens{}, &domain.OAuthError{Code: "invalid_scope", Description: "scope " + scope + " is not allowed"}
+		}
+	}
+
+	ttl := client.TokenTTL
+	if ttl == 0 {
+		ttl = a.Settings.AccessTTL
+	}
+	accessToken, err := authJWT.CreateServiceToken(client.ClientID, scopes, ttl, a.Settings)
+	if err != nil {
+		a.Logger.Error(op, slog.String(op, err.Error()))
+		return domain.OIDCTokens{}, err
+	}
+	return domain.OIDCTokens{AccessToken: accessToken, ExpiresIn: ttl, Scopes: scopes}, nil
+}
@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/SeiFlow-3P2/auth_service/internal/domain"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"log/slog"
+)
+
+// linkIdentity links external account to the user. The account must not belong to another user.
+func (a *Auth) linkIdentity(ctx context.Context, user *domain.User, provider string, subject string, email string, sessionID string) (*domain.Identity, error) {
+	identity, err := a.AuthDB.GetIdentity(provider, subject)
+	if err == nil {
+		if identity.UserID != user.ID {
+			return nil, domain.ErrIdentityLinked
+		}
+		return identity, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	identity = &domain.Identity{UserID: user.ID, Provider: provider, Subject: subject, Email: email}
+	if err := a.AuthDB.CreateIdentity(identity); err != nil {
+		return nil, err
+	}
+	a.securityEvent(ctx, user.ID, domain.EventIdentityLinked, sessionID, provider+":"+subject)
+	return identity, nil
+}
+
+// identitySubject returns id of the user at oauth provider
+func identitySubject(info *domain.UserInfo) string {
+	if info.ID != "" {
+		return info.ID
+	}
+	return info.Email
+}
+
+// ListIdentities returns login methods of the current user: email with password and linked accounts
+func (a *Auth) ListIdentities(ctx context.Context, accessToken string) (identities []domain.Identity, err error) {
+	op := "Auth_Service_ListIdentities: "
+
+	_, user, err := a.currentUser(ctx, accessToken)
+	if err != nil {
+		return nil, err
+	}
+	linked, err := a.AuthDB.GetIdentities(user.ID)
+	if err != nil {
+		a.Logger.Error(op, slog.String(op, err.Error()))
+		return nil, err
+	}
+
+	if len(user.PasswordHash) > 0 {
+		identities = append(identities, domain.Identity{
+			UserID:   user.ID,
+			Provider: domain.ProviderEmail,
+			Subject:  user.Email,
+			Email:    user.Email,
+			LinkedAt: user.CreatedAt,
+		})
+	}
+	return append(identities, linked...), nil
+}
+
+// LinkIdentity links oauth or telegram account to the current user.
+// oauthToken is used for oauth providers, telegramData for telegram.
+func (a *Auth) LinkIdentity(ctx context.Context, accessToken string, provider string, oauthToken string, telegramData map[string]string) (identity *domain.Identity, err error) {
+	op := "Auth_Service_LinkIdentity: "
+
+	session, user, err := a.currentUser(ctx, accessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	if provider == domain.ProviderTelegram {
+		identity, err = a.linkTelegram(ctx, session.ID, user, telegramData)
+	} else {
+		if _, ok := a.OauthProviders[provider]; !ok {
+			return nil, domain.ErrUnknownProvider
+		}
+		var info *domain.UserInfo
+		info, err = a.oauthUserInfo(ctx, provider, oauthToken)
+		if err != nil {
+			return nil, err
+		}
+		identity, err = a.linkIdentity(ctx, user, provider, identitySubject(info), info.Email, session.ID)
+	}
+	if err != nil {
+		if !errors.Is(err, domain.ErrIdentityLinked) && !errors.Is(err, domain.ErrInvalidTelegram) {
+			a.Logger.Error(op, slog.String(op, err.Error()))
+		}
+		return nil, err
+	}
+	return identity, nil
+}
+
+// UnlinkIdentity removes linked account of the current user.
+// The user must keep at least one way to log in: password, linked account or passkey.
+func (a *Auth) UnlinkIdentity(ctx context.Context, accessToken string, identityID uuid.UUID) (err error) {
+	op := "Auth_Service_UnlinkIdentity: "
+
+	session, user, err := a.currentUser(ctx, accessToken)
+	if err != nil {
+		return err
+	}
+	identities, err := a.AuthDB.GetIdentities(user.ID)
+	if err != nil {
+		return err
+	}
+	var identity *domain.Identity
+	for i := range identities {
+		if identities[i].ID == identityID {
+			identity = &identities[i]
+		}
+	}
+	if identity == nil {
+		return domain.ErrIdentityNotFound
+	}
+
+	passkeys, err := a.AuthDB.GetWebAuthnCredentials(user.ID)
+	if err != nil {
+		return err
+	}
+	methods := len(identities) + len(passkeys)
+	if len(user.PasswordHash) > 0 {
+		methods++
+	}
+	if methods <= 1 {
+		return domain.ErrLastLoginMethod
+	}
+
+	if err := a.AuthDB.DeleteIdentity(user.ID, identity.ID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domain.ErrIdentityNotFound
+		}
+		a.Logger.Error(op, slog.String(op, err.Error()))
+		return err
+	}
+	if identity.Provider == domain.ProviderTelegram {
+		// Без связи telegram id больше не подтверждён
+		if err := a.AuthDB.ChangeTelegramId(user.ID, 0); err != nil {
+			a.Logger.Error(op, slog.String(op, err.Error()))
+		}
+	}
+
+	a.securityEvent(ctx, user.ID, domain.EventIdentityUnlinked, session.ID, identity.Provider+":"+identity.Subject)
+	return nil
+}
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/SeiFlow-3P2/auth_service/internal/domain"
+	"github.com/SeiFlow-3P2/auth_service/pkg/authJWT"
+	"github.com/SeiFlow-3P2/auth_service/pkg/authRedis"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"log/slog"
+	"slices"
+)
+
+// Introspect checks token signature, registered claims and that its session is still active.
+// Invalid token is not an error, it is reported as inactive (RFC 7662). The caller authenticates
+// with token of service client that has the introspect scope.
+func (a *Auth) Introspect(ctx context.Context, callerToken string, token string) (info domain.TokenInfo, err error) {
+	op := "Auth_Service_Introspect: "
+
+	if err := a.authenticateIntrospection(callerToken); err != nil {
+		return domain.TokenInfo{}, err
+	}
+
+	claims, err := authJWT.ParseToken(token, "", a.Settings)
+	if err != nil {
+		a.Logger.Debug(op, slog.String(op, err.Error()))
+		return domain.TokenInfo{}, nil
+	}
+
+	if claims.IsService() {
+		return a.introspectService(claims)
+	}
+
+	session, err := a.Casher.GetSession(ctx, claims.SessionID)
+	if err != nil {
+		if errors.Is(err, authRedis.ErrSessionNotFound) {
+			return domain.TokenInfo{}, nil
+		}
+		return domain.TokenInfo{}, err
+	}
+	if session.UserID != claims.Subject {
+		return domain.TokenInfo{}, nil
+	}
+	// Уже заменённый refresh токен недействителен, хоть подпись и верна
+	if claims.TokenType == authJWT.TokenTypeRefresh && claims.ID != session.RefreshID {
+		return domain.TokenInfo{}, nil
+	}
+
+	userID, err := uuid.Parse(claims.Subject)
+	if err != nil {
+		return domain.TokenInfo{}, nil
+	}
+	user, err := a.AuthDB.GetUser(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domain.TokenInfo{}, nil
+		}
+		return domain.TokenInfo{}, err
+	}
+
+	info = domain.TokenInfo{
+		Active:      true,
+		TokenType:   claims.TokenType,
+		UserID:      user.ID.String(),
+		Email:       user.Email,
+		Username:    user.Username,
+		TelegramID:  user.TelegramId,
+		SessionID:   session.ID,
+		Scopes:      claims.Scopes(),
+		ClientID:    claims.ClientID,
+		SubjectType: authJWT.SubjectTypeUser,
+		IssuedAt:    claims.IssuedAt.Time,
+		ExpiresAt:   claims.ExpiresAt.Time,
+	}
+	return info, nil
+}
+
+// authenticateIntrospection checks that the caller is active service client allowed to introspect tokens
+func (a *Auth) authenticateIntrospection(callerToken string) error {
+	claims, err := authJWT.ParseToken(callerToken, authJWT.TokenTypeAccess, a.Settings)
+	if err != nil || !claims.IsService() {
+		return domain.ErrUnauthenticated
+	}
+	caller, err := a.introspectService(claims)
+	if err != nil {
+		return err
+	}
+	if !caller.Active {
+		return domain.ErrUnauthenticated
+	}
+	if !slices.Contains(caller.Scopes, domain.ScopeIntrospect) {
+		return domain.ErrForbidden
+	}
+	return nil
+}
+
+// introspectService checks token of the service, it is active while the client is registered
+func (a *Auth) introspectService(claims *authJWT.Claims) (domain.TokenInfo, error) {
+	if claims.Subject != claims.ClientID {
+		return domain.TokenInfo{}, nil
+	}
+	client, err := a.oauthClient(claims.ClientID)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidClient) {
+			return domain.TokenInfo{}, nil
+		}
+		return domain.TokenInfo{}, err
+	}
+	if !client.AllowsGrant(domain.GrantClientCredentials) {
+		return domain.TokenInfo{}, nil
+	}
+
+	return domain.TokenInfo{
+		Active:      true,
+		TokenType:   claims.TokenType,
+		Scopes:      claims.Scopes(),
+		ClientID:    client.ClientID,
+		SubjectType: authJWT.SubjectTypeService,
+		IssuedAt:    claims.IssuedAt.Time,
+		ExpiresAt:   claims.ExpiresAt.Time,
+	}, nil
+}
@@ -0,0 +1,26 @@
+package service
+
+import (
+	"context"
+	"github.com/SeiFlow-3P2/auth_service/pkg/authKeys"
+)
+
+// GetJWKS returns public keys that verify access tokens, including
+// keys that are not active yet and previous keys that still have live tokens
+func (a *Auth) GetJWKS(ctx context.Context) (jwks authKeys.JWKS, err error) {
+	jwks.Keys = []authKeys.JWK{}
+
+	// С HS256 секретом публиковать нечего
+	if a.Settings.Keys == nil {
+		return jwks, nil
+	}
+
+	for _, key := range a.Settings.Keys.Keys() {
+		jwk, err := key.JWK()
+		if err != nil {
+			return authKeys.JWKS{}, err
+		}
+		jwks.Keys = append(jwks.Keys, jwk)
+	}
+	return jwks, nil
+}
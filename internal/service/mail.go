@@ -0,0 +1,20 @@
+package service
+
+import (
+	"context"
+	"github.com/SeiFlow-3P2/auth_service/pkg/utils/clientinfo"
+)
+
+// sendMail renders template in the client language and sends it.
+// Письмо может уйти в очередь и отправиться после ответа, поэтому отмена запроса его не прерывает.
+func (a *Auth) sendMail(ctx context.Context, to string, template string, data any) error {
+	if !mailable(to) {
+		return nil
+	}
+	mail, err := a.MailTemplates.Render(template, clientinfo.Language(ctx), data)
+	if err != nil {
+		return err
+	}
+	mail.To = to
+	return a.Mailer.Send(context.WithoutCancel(ctx), mail)
+}
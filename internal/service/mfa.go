@@ -0,0 +1,357 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"errors"
+	"github.com/SeiFlow-3P2/auth_service/internal/domain"
+	"github.com/SeiFlow-3P2/auth_service/pkg/authRedis"
+	"github.com/SeiFlow-3P2/auth_service/pkg/authTOTP"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+const (
+	recoveryCodesCount = 10
+	// maxMFAAttempts попыток ввести код на один вход по паролю
+	maxMFAAttempts = 5
+	// totpSkew допускает расхождение часов телефона на один шаг
+	totpSkew = 1
+)
+
+// normalizeRecoveryCode lets user type code in any case and without dash
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
+
+// hashRecoveryCode returns hash of recovery code. Codes are random enough
+// for plain sha256, slow password hash is not needed.
+func hashRecoveryCode(code string) []byte {
+	sum := sha256.Sum256([]byte(normalizeRecoveryCode(code)))
+	return sum[:]
+}
+
+// generateRecoveryCodes returns codes like "k3j9d-x7q2m" and their hashes
+func generateRecoveryCodes() (codes []string, hashes [][]byte, err error) {
+	encoding := base32.StdEncoding.WithPadding(base32.NoPadding)
+	for i := 0; i < recoveryCodesCount; i++ {
+		raw := make([]byte, 8)
+		if _, err := rand.Read(raw); err != nil {
+			return nil, nil, err
+		}
+		code := strings.ToLower(encoding.EncodeToString(raw)[:10])
+		code = code[:5] + "-" + code[5:]
+		codes = append(codes, code)
+		hashes = append(hashes, hashRecoveryCode(code))
+	}
+	return codes, hashes, nil
+}
+
+// userMFA returns MFA of the user or nil if it was never enrolled
+func (a *Auth) userMFA(userID uuid.UUID) (*domain.UserMFA, error) {
+	mfa, err := a.AuthDB.GetMFA(userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return mfa, nil
+}
+
+// hasSecondFactor reports whether the user confirmed TOTP or registered a passkey
+func (a *Auth) hasSecondFactor(user *domain.User) (bool, error) {
+	mfa, err := a.userMFA(user.ID)
+	if err != nil {
+		return false, err
+	}
+	if mfa.Enabled() {
+		return true, nil
+	}
+	if a.WebAuthn == nil {
+		return false, nil
+	}
+	// Passkey подходит вторым фактором и без TOTP, его проверяет VerifyMFAPasskey
+	passkeys, err := a.AuthDB.GetWebAuthnCredentials(user.ID)
+	if err != nil {
+		return false, err
+	}
+	return len(passkeys) > 0, nil
+}
+
+// loginTokens issues tokens if the user has no second factor, otherwise
+// starts MFA challenge and returns *domain.MFARequiredError
+func (a *Auth) loginTokens(ctx context.Context, user *domain.User) (domain.Tokens, error) {
+	required, err := a.hasSecondFactor(user)
+	if err != nil {
+		return domain.Tokens{}, err
+	}
+	if !required {
+		return a.issueTokens(ctx, user)
+	}
+
+	challenge, err := a.startMFAChallenge(ctx, user)
+	if err != nil {
+		return domain.Tokens{}, err
+	}
+	return domain.Tokens{}, challenge
+}
+
+// startMFAChallenge stores login of the user waiting for the second factor
+func (a *Auth) startMFAChallenge(ctx context.Context, user *domain.User) (*domain.MFARequiredError, error) {
+	challenge, err := randomToken()
+	if err != nil {
+		return nil, err
+	}
+
+	ttl := a.Settings.MFAChallengeTTL
+	if err := a.Casher.SetMFAChallenge(ctx, hashToken(challenge), user.ID.String(), ttl); err != nil {
+		return nil, err
+	}
+	return &domain.MFARequiredError{UserID: user.ID, Challenge: challenge, ExpiresAt: time.Now().Add(ttl)}, nil
+}
+
+// mfaThrottleKey counts wrong codes of the user across all MFA challenges
+func (a *Auth) mfaThrottleKey(userID uuid.UUID) throttleKey {
+	return throttleKey{
+		key:         "mfa:" + userID.String(),
+		maxFailures: a.Settings.LoginThrottle.MaxMFAFailures,
+		event:       domain.EventMFALockout,
+	}
+}
+
+// checkMFACodeThrottled is checkMFACode limited by the user MFA failures counter.
+// Returns *domain.RetryError while the second factor of the user is locked.
+func (a *Auth) checkMFACodeThrottled(ctx context.Context, user *domain.User, mfa *domain.UserMFA, code string) error {
+	op := "Auth_Service_checkMFACodeThrottled: "
+
+	k := a.mfaThrottleKey(user.ID)
+	id, count, locked, err := a.Casher.LoginAttempt(ctx, k.key, a.Settings.LoginThrottle.Window, k.maxFailures)
+	if err != nil {
+		return err
+	}
+	if locked > 0 {
+		return &domain.RetryError{RetryAfter: locked}
+	}
+	attempts := []loginAttempt{{throttleKey: k, id: id, count: count}}
+
+	err = a.checkMFACode(ctx, mfa, code)
+	switch {
+	case err == nil:
+		if err := a.Casher.ClearLoginFailures(ctx, k.key); err != nil {
+			a.Logger.Error(op, slog.String(op, err.Error()))
+		}
+	case errors.Is(err, domain.ErrInvalidMFACode):
+		a.loginFailed(ctx, user, attempts)
+	default:
+		a.forgetAttempts(ctx, attempts)
+	}
+	return err
+}
+
+// checkMFACode accepts current TOTP code or unused recovery code
+func (a *Auth) checkMFACode(ctx context.Context, mfa *domain.UserMFA, code string) error {
+	if counter, ok := authTOTP.Validate(mfa.Secret, code, time.Now(), totpSkew); ok {
+		used, err := a.AuthDB.UseMFACounter(mfa.UserID, counter)
+		if err != nil {
+			return err
+		}
+		if !used {
+			// Этот код уже использовали
+			return domain.ErrInvalidMFACode
+		}
+		return nil
+	}
+
+	used, err := a.AuthDB.UseRecoveryCode(mfa.UserID, hashRecoveryCode(code))
+	if err != nil {
+		return err
+	}
+	if !used {
+		return domain.ErrInvalidMFACode
+	}
+	a.securityEvent(ctx, mfa.UserID, domain.EventRecoveryCodeUsed, "", "")
+	return nil
+}
+
+// EnrollMFA creates new TOTP secret of the user. It is not required on login until confirmed.
+func (a *Auth) EnrollMFA(ctx context.Context, accessToken string) (secret string, uri string, err error) {
+	op := "Auth_Service_EnrollMFA: "
+
+	_, user, err := a.currentUser(ctx, accessToken)
+	if err != nil {
+		return "", "", err
+	}
+	mfa, err := a.userMFA(user.ID)
+	if err != nil {
+		return "", "", err
+	}
+	if mfa.Enabled() {
+		return "", "", domain.ErrMFAEnabled
+	}
+
+	secret, err = authTOTP.GenerateSecret()
+	if err != nil {
+		return "", "", err
+	}
+	if err := a.AuthDB.SaveMFA(&domain.UserMFA{UserID: user.ID, Secret: secret}); err != nil {
+		a.Logger.Error(op, slog.String(op, err.Error()))
+		return "", "", err
+	}
+	return secret, authTOTP.URI(a.Settings.MFAIssuer, user.Email, secret), nil
+}
+
+// ConfirmMFA enables MFA after the first valid code and returns recovery codes.
+// Recovery codes are shown only once, only their hashes are stored.
+func (a *Auth) ConfirmMFA(ctx context.Context, accessToken string, code string) (recoveryCodes []string, err error) {
+	op := "Auth_Service_ConfirmMFA: "
+
+	session, user, err := a.currentUser(ctx, accessToken)
+	if err != nil {
+		return nil, err
+	}
+	mfa, err := a.userMFA(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if mfa == nil {
+		return nil, domain.ErrMFANotEnabled
+	}
+	if mfa.Enabled() {
+		return nil, domain.ErrMFAEnabled
+	}
+
+	counter, ok := authTOTP.Validate(mfa.Secret, code, time.Now(), totpSkew)
+	if !ok {
+		return nil, domain.ErrInvalidMFACode
+	}
+
+	recoveryCodes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	if err := a.AuthDB.ReplaceRecoveryCodes(user.ID, hashes); err != nil {
+		a.Logger.Error(op, slog.String(op, err.Error()))
+		return nil, err
+	}
+
+	now := time.Now()
+	mfa.ConfirmedAt = &now
+	mfa.LastCounter = counter
+	if err := a.AuthDB.SaveMFA(mfa); err != nil {
+		a.Logger.Error(op, slog.String(op, err.Error()))
+		return nil, err
+	}
+
+	a.securityEvent(ctx, user.ID, domain.EventMFAEnabled, session.ID, "")
+	return recoveryCodes, nil
+}
+
+// DisableMFA removes second factor. Requires current password, if the user has one, and MFA code.
+func (a *Auth) DisableMFA(ctx context.Context, accessToken string, password []byte, code string) (err error) {
+	op := "Auth_Service_DisableMFA: "
+
+	session, user, err := a.currentUser(ctx, accessToken)
+	if err != nil {
+		return err
+	}
+	mfa, err := a.userMFA(user.ID)
+	if err != nil {
+		return err
+	}
+	if !mfa.Enabled() {
+		return domain.ErrMFANotEnabled
+	}
+
+	if len(user.PasswordHash) > 0 {
+		if err := a.checkPassword(user, password); err != nil {
+			return err
+		}
+	}
+	if err := a.checkMFACodeThrottled(ctx, user, mfa, code); err != nil {
+		return err
+	}
+
+	if err := a.AuthDB.DeleteMFA(user.ID); err != nil {
+		a.Logger.Error(op, slog.String(op, err.Error()))
+		return err
+	}
+	a.securityEvent(ctx, user.ID, domain.EventMFADisabled, session.ID, "")
+	return nil
+}
+
+// mfaChallengeUser counts attempt to pass the challenge and returns user who started it
+func (a *Auth) mfaChallengeUser(ctx context.Context, key string) (*domain.User, int64, error) {
+	id, attempts, err := a.Casher.MFAChallengeAttempt(ctx, key)
+	if err != nil {
+		if errors.Is(err, authRedis.ErrChallengeNotFound) {
+			return nil, 0, domain.ErrInvalidToken
+		}
+		return nil, 0, err
+	}
+	if attempts > maxMFAAttempts {
+		_, _ = a.Casher.DeleteMFAChallenge(ctx, key)
+		return nil, 0, domain.ErrInvalidToken
+	}
+
+	userID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, 0, domain.ErrInvalidToken
+	}
+	user, err := a.AuthDB.GetUser(userID)
+	if err != nil {
+		return nil, 0, err
+	}
+	return user, attempts, nil
+}
+
+// finishMFAChallenge removes passed challenge and issues tokens
+func (a *Auth) finishMFAChallenge(ctx context.Context, key string, user *domain.User) (userID uuid.UUID, accessToken string, refreshToken string, err error) {
+	// Челлендж одноразовый, параллельный запрос с другим кодом уже мог его использовать
+	deleted, err := a.Casher.DeleteMFAChallenge(ctx, key)
+	if err != nil {
+		return uuid.Nil, "", "", err
+	}
+	if !deleted {
+		return uuid.Nil, "", "", domain.ErrInvalidToken
+	}
+
+	tokens, err := a.issueTokens(ctx, user)
+	if err != nil {
+		return uuid.Nil, "", "", err
+	}
+	return user.ID, tokens.AccessToken, tokens.RefreshToken, nil
+}
+
+// VerifyMFA completes login started with password by TOTP or recovery code
+func (a *Auth) VerifyMFA(ctx context.Context, challenge string, code string) (userID uuid.UUID, accessToken string, refreshToken string, err error) {
+	op := "Auth_Service_VerifyMFA: "
+	key := hashToken(challenge)
+
+	user, attempts, err := a.mfaChallengeUser(ctx, key)
+	if err != nil {
+		return uuid.Nil, "", "", err
+	}
+	mfa, err := a.userMFA(user.ID)
+	if err != nil {
+		return uuid.Nil, "", "", err
+	}
+	if !mfa.Enabled() {
+		return uuid.Nil, "", "", domain.ErrInvalidToken
+	}
+
+	if err := a.checkMFACodeThrottled(ctx, user, mfa, code); err != nil {
+		if errors.Is(err, domain.ErrInvalidMFACode) {
+			a.Logger.Info(op+"invalid code", slog.String("user_id", user.ID.String()), slog.Int64("attempt", attempts))
+		}
+		return uuid.Nil, "", "", err
+	}
+
+	return a.finishMFAChallenge(ctx, key, user)
+}
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"github.com/SeiFlow-3P2/auth_service/internal/domain"
+	"github.com/SeiFlow-3P2/auth_service/pkg/authRedis"
+	"github.com/google/uuid"
+	"golang.org/x/oauth2"
+	"log/slog"
+)
+
+// oauthState is authorization request waiting for the provider callback
+type oauthState struct {
+	Provider string `json:"provider"`
+	Verifier string `json:"verifier"`
+	// Binding хеш значения из cookie браузера, который начал вход
+	Binding string `json:"binding"`
+}
+
+// randomToken returns 256 bit random url-safe string
+func randomToken() (string, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// BeginOAuth returns URL of the provider consent page and binding the caller keeps
+// in the browser. State, PKCE verifier and hash of the binding are kept in casher
+// until the provider redirects the user back to the callback.
+func (a *Auth) BeginOAuth(ctx context.Context, provider string) (authURL string, binding string, err error) {
+	op := "Auth_Service_BeginOAuth: "
+
+	p, ok := a.OauthProviders[provider]
+	if !ok {
+		return "", "", domain.ErrUnknownProvider
+	}
+	if ready, ok := p.(domain.OauthProviderReady); ok {
+		if err := ready.Ready(ctx); err != nil {
+			a.Logger.Error(op, slog.String(op, err.Error()))
+			return "", "", domain.ErrProviderDown
+		}
+	}
+	state, err := randomToken()
+	if err != nil {
+		return "", "", err
+	}
+	binding, err = randomToken()
+	if err != nil {
+		return "", "", err
+	}
+	verifier := oauth2.GenerateVerifier()
+
+	data, err := json.Marshal(oauthState{Provider: provider, Verifier: verifier, Binding: hashToken(binding)})
+	if err != nil {
+		return "", "", err
+	}
+	if err := a.Casher.SetOAuthState(ctx, hashToken(state), data, a.Settings.OAuthStateTTL); err != nil {
+		a.Logger.Error(op, slog.String(op, err.Error()))
+		return "", "", err
+	}
+	return p.AuthCodeURL(state, oauth2.S256ChallengeOption(verifier)), binding, nil
+}
+
+// FinishOAuth handles provider callback: checks state and that it came back to the browser
+// that started the login, exchanges the code and returns one-time login code for the
+// frontend, or MFA challenge if the user has second factor.
+func (a *Auth) FinishOAuth(ctx context.Context, provider string, state string, code string, binding string) (loginCode string, mfaChallenge string, err error) {
+	op := "Auth_Service_FinishOAuth: "
+
+	data, err := a.Casher.TakeOAuthState(ctx, hashToken(state))
+	if err != nil {
+		if errors.Is(err, authRedis.ErrOAuthStateNotFound) {
+			return "", "", domain.ErrInvalidToken
+		}
+		return "", "", err
+	}
+	var request oauthState
+	if err := json.Unmarshal(data, &request); err != nil {
+		return "", "", err
+	}
+	p, ok := a.OauthProviders[request.Provider]
+	if !ok || request.Provider != provider {
+		return "", "", domain.ErrInvalidToken
+	}
+	// Иначе злоумышленник мог бы подсунуть жертве ссылку со своим state и кодом (login CSRF)
+	if binding == "" || subtle.ConstantTimeCompare([]byte(hashToken(binding)), []byte(request.Binding)) != 1 {
+		return "", "", domain.ErrInvalidToken
+	}
+
+	token, err := p.Exchange(ctx, code, oauth2.VerifierOption(request.Verifier))
+	if err != nil {
+		a.Logger.Info(op, slog.String(op, err.Error()))
+		return "", "", domain.ErrInvalidToken
+	}
+	info, err := p.UserInfo(ctx, token)
+	if err != nil {
+		a.Logger.Error(op, slog.String(op, err.Error()))
+		return "", "", err
+	}
+	user, err := a.oauthUser(ctx, info, 0)
+	if err != nil {
+		a.Logger.Error(op, slog.String(op, err.Error()))
+		return "", "", err
+	}
+
+	hasSecondFactor, err := a.hasSecondFactor(user)
+	if err != nil {
+		return "", "", err
+	}
+	if hasSecondFactor {
+		required, err := a.startMFAChallenge(ctx, user)
+		if err != nil {
+			return "", "", err
+		}
+		return "", required.Challenge, nil
+	}
+
+	// Токены выдаются при обмене кода, чтобы сессия получила устройство и IP клиента
+	loginCode, err = randomToken()
+	if err != nil {
+		return "", "", err
+	}
+	if err := a.Casher.SetLoginCode(ctx, hashToken(loginCode), user.ID.String(), a.Settings.LoginCodeTTL); err != nil {
+		a.Logger.Error(op, slog.String(op, err.Error()))
+		return "", "", err
+	}
+	return loginCode, "", nil
+}
+
+// ExchangeLoginCode issues tokens for one-time code from the OAuth callback
+func (a *Auth) ExchangeLoginCode(ctx context.Context, code string) (userID uuid.UUID, accessToken string, refreshToken string, err error) {
+	id, err := a.Casher.TakeLoginCode(ctx, hashToken(code))
+	if err != nil {
+		if errors.Is(err, authRedis.ErrLoginCodeNotFound) {
+			return uuid.Nil, "", "", domain.ErrInvalidToken
+		}
+		return uuid.Nil, "", "", err
+	}
+	userID, err = uuid.Parse(id)
+	if err != nil {
+		return uuid.Nil, "", "", domain.ErrInvalidToken
+	}
+	user, err := a.AuthDB.GetUser(userID)
+	if err != nil {
+		return uuid.Nil, "", "", err
+	}
+
+	tokens, err := a.issueTokens(ctx, user)
+	if err != nil {
+		return uuid.Nil, "", "", err
+	}
+	return user.ID, tokens.AccessToken, tokens.RefreshToken, nil
+}
@@ -0,0 +1,341 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/SeiFlow-3P2/auth_service/internal/domain"
+	"github.com/SeiFlow-3P2/auth_service/pkg/authJWT"
+	"github.com/SeiFlow-3P2/auth_service/pkg/authRedis"
+	"github.com/SeiFlow-3P2/auth_service/pkg/utils/clientinfo"
+	"github.com/google/uuid"
+	"golang.org/x/oauth2"
+	"gorm.io/gorm"
+	"log/slog"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+// oidcEnabled ID токены проверяются приложениями по JWKS, без асимметричного ключа провайдер выключен
+func (a *Auth) oidcEnabled() error {
+	if a.Settings.Keys == nil {
+		return domain.ErrOIDCDisabled
+	}
+	return nil
+}
+
+// withQuery returns uri with query parameters added
+func withQuery(uri string, params map[string]string) (string, error) {
+	target, err := url.Parse(uri)
+	if err != nil {
+		return "", err
+	}
+	query := target.Query()
+	for key, value := range params {
+		if value != "" {
+			query.Set(key, value)
+		}
+	}
+	target.RawQuery = query.Encode()
+	return target.String(), nil
+}
+
+// Authorize validates authorization request of the client and returns URL of the login
+// and consent page. Unknown client or redirect uri must not redirect back to the client,
+// other errors are *domain.OAuthError for the redirect uri.
+func (a *Auth) Authorize(ctx context.Context, request domain.AuthorizationRequest) (loginURL string, err error) {
+	op := "Auth_Service_Authorize: "
+
+	if err := a.oidcEnabled(); err != nil {
+		return "", err
+	}
+	client, err := a.oauthClient(request.ClientID)
+	if err != nil {
+		return "", err
+	}
+	// Сервисы с client_credentials не входят от имени пользователей
+	if !client.AllowsGrant(domain.GrantAuthorizationCode) {
+		return "", domain.ErrInvalidClient
+	}
+	if !client.AllowsRedirect(request.RedirectURI) {
+		return "", domain.ErrInvalidRedirect
+	}
+
+	// Ошибки ниже уже можно вернуть клиенту на его redirect uri
+	if request.ResponseType != "code" {
+		return "", &domain.OAuthError{Code: "unsupported_response_type", Description: "only code flow is supported"}
+	}
+	if request.CodeChallenge != "" && request.CodeChallengeMethod != "S256" {
+		return "", &domain.OAuthError{Code: "invalid_request", Description: "only S256 code_challenge_method is supported"}
+	}
+	prompt := strings.Fields(request.Prompt)
+	// Сессии в браузере у сервиса нет, без страницы входа пользователя не узнать
+	if slices.Contains(prompt, "none") {
+		return "", &domain.OAuthError{Code: "login_required"}
+	}
+	request.ForceConsent = slices.Contains(prompt, "consent")
+
+	request.Scopes = slices.Compact(slices.Sorted(slices.Values(request.Scopes)))
+	if !slices.Contains(request.Scopes, domain.ScopeOpenID) {
+		return "", &domain.OAuthError{Code: "invalid_scope", Description: "openid scope is required"}
+	}
+	allowed := client.AllowedScopes()
+	for _, scope := range request.Scopes {
+		if !slices.Contains(allowed, scope) {
+			return "", &domain.OAuthError{Code: "invalid_scope", Description: "scope " + scope + " is not allowed"}
+		}
+	}
+	if client.Public() && request.CodeChallenge == "" {
+		return "", &domain.OAuthError{Code: "invalid_request", Description: "code_challenge is required"}
+	}
+	request.ClientName = client.Name
+
+	requestID, err := randomToken()
+	if err != nil {
+		return "", err
+	}
+	data, err := json.Marshal(request)
+	if err != nil {
+		return "", err
+	}
+	if err := a.Casher.SetAuthorizationRequest(ctx, hashToken(requestID), data, a.Settings.OIDCRequestTTL); err != nil {
+		a.Logger.Error(op, slog.String(op, err.Error()))
+		return "", err
+	}
+	return withQuery(a.Settings.OIDCLoginURL, map[string]string{"request_id": requestID})
+}
+
+// consentRequired reports whether the user has to confirm access of the client to scopes
+func (a *Auth) consentRequired(userID uuid.UUID, client *domain.OAuthClient, request *domain.AuthorizationRequest) (bool, error) {
+	if request.ForceConsent {
+		return true, nil
+	}
+	if client.SkipConsent {
+		return false, nil
+	}
+	consent, err := a.AuthDB.GetOAuthConsent(userID, client.ClientID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return !consent.Covers(request.Scopes), nil
+}
+
+// authorizationRequest decodes authorization request stored by Authorize
+func authorizationRequest(data []byte, err error) (*domain.AuthorizationRequest, error) {
+	if err != nil {
+		if errors.Is(err, authRedis.ErrAuthorizationRequestNotFound) {
+			return nil, domain.ErrRequestNotFound
+		}
+		return nil, err
+	}
+	var request domain.AuthorizationRequest
+	if err := json.Unmarshal(data, &request); err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
+
+// GetAuthorizationRequest returns authorization request for the login page of the current user,
+// the page asks for consent if ConsentRequired is set
+func (a *Auth) GetAuthorizationRequest(ctx context.Context, accessToken string, requestID string) (request *domain.AuthorizationRequest, err error) {
+	_, user, err := a.currentUser(ctx, accessToken)
+	if err != nil {
+		return nil, err
+	}
+	request, err = authorizationRequest(a.Casher.GetAuthorizationRequest(ctx, hashToken(requestID)))
+	if err != nil {
+		return nil, err
+	}
+	client, err := a.oauthClient(request.ClientID)
+	if err != nil {
+		return nil, err
+	}
+
+	request.ConsentRequired, err = a.consentRequired(user.ID, client, request)
+	if err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+// ApproveAuthorization answers authorization request on behalf of the current user and returns
+// redirect uri of the client with authorization code, or with access_denied if the user refused
+func (a *Auth) ApproveAuthorization(ctx context.Context, accessToken string, requestID string, approve bool) (redirectURI string, err error) {
+	op := "Auth_Service_ApproveAuthorization: "
+
+	session, user, err := a.currentUser(ctx, accessToken)
+	if err != nil {
+		return "", err
+	}
+	request, err := authorizationRequest(a.Casher.TakeAuthorizationRequest(ctx, hashToken(requestID)))
+	if err != nil {
+		return "", err
+	}
+	if !approve {
+		return withQuery(request.RedirectURI, map[string]string{
+			"error": "access_denied", "state": request.State, "iss": a.Settings.Issuer,
+		})
+	}
+	client, err := a.oauthClient(request.ClientID)
+	if err != nil {
+		return "", err
+	}
+
+	required, err := a.consentRequired(user.ID, client, request)
+	if err != nil {
+		return "", err
+	}
+	if required && !client.SkipConsent {
+		err = a.AuthDB.SaveOAuthConsent(&domain.OAuthConsent{
+			UserID:    user.ID,
+			ClientID:  client.ClientID,
+			Scopes:    strings.Join(request.Scopes, " "),
+			GrantedAt: time.Now(),
+		})
+		if err != nil {
+			a.Logger.Error(op, slog.String(op, err.Error()))
+			return "", err
+		}
+		a.securityEvent(ctx, user.ID, domain.EventOAuthConsent, session.ID, client.ClientID+": "+strings.Join(request.Scopes, " "))
+	}
+
+	code, err := randomToken()
+	if err != nil {
+		return "", err
+	}
+	data, err := json.Marshal(domain.AuthorizationGrant{
+		UserID:        user.ID.String(),
+		ClientID:      client.ClientID,
+		RedirectURI:   request.RedirectURI,
+		Scopes:        request.Scopes,
+		Nonce:         request.Nonce,
+		CodeChallenge: request.CodeChallenge,
+		// Пользователь аутентифицировался при создании сессии, а не сейчас
+		AuthTime: session.CreatedAt,
+	})
+	if err != nil {
+		return "", err
+	}
+	if err := a.Casher.SetAuthorizationCode(ctx, hashToken(code), data, a.Settings.AuthorizationCodeTTL); err != nil {
+		a.Logger.Error(op, slog.String(op, err.Error()))
+		return "", err
+	}
+	// iss по RFC 9207 защищает клиентов, работающих с несколькими провайдерами, от подмены ответа
+	return withQuery(request.RedirectURI, map[string]string{
+		"code": code, "state": request.State, "iss": a.Settings.Issuer,
+	})
+}
+
+// ExchangeAuthorizationCode issues access and ID tokens to the client for authorization code
+func (a *Auth) ExchangeAuthorizationCode(ctx context.Context, clientID string, clientSecret string, code string, redirectURI string, codeVerifier string) (tokens domain.OIDCTokens, err error) {
+	op := "Auth_Service_ExchangeAuthorizationCode: "
+
+	if err := a.oidcEnabled(); err != nil {
+		return domain.OIDCTokens{}, err
+	}
+	client, err := a.authenticateClient(clientID, clientSecret)
+	if err != nil {
+		return domain.OIDCTokens{}, err
+	}
+
+	invalidGrant := &domain.OAuthError{Code: "invalid_grant", Description: "invalid or expired authorization code"}
+	data, err := a.Casher.TakeAuthorizationCode(ctx, hashToken(code))
+	if err != nil {
+		if errors.Is(err, authRedis.ErrAuthorizationCodeNotFound) {
+			return domain.OIDCTokens{}, invalidGrant
+		}
+		return domain.OIDCTokens{}, err
+	}
+	var grant domain.AuthorizationGrant
+	if err := json.Unmarshal(data, &grant); err != nil {
+		return domain.OIDCTokens{}, err
+	}
+	if grant.ClientID != client.ClientID || grant.RedirectURI != redirectURI {
+		return domain.OIDCTokens{}, invalidGrant
+	}
+	// Без challenge verifier тоже не принимается: иначе PKCE можно обойти, убрав его из запроса авторизации
+	if (grant.CodeChallenge == "") != (codeVerifier == "") ||
+		(codeVerifier != "" && oauth2.S256ChallengeFromVerifier(codeVerifier) != grant.CodeChallenge) {
+		return domain.OIDCTokens{}, &domain.OAuthError{Code: "invalid_grant", Description: "code_verifier does not match"}
+	}
+
+	userID, err := uuid.Parse(grant.UserID)
+	if err != nil {
+		return domain.OIDCTokens{}, invalidGrant
+	}
+	user, err := a.AuthDB.GetUser(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domain.OIDCTokens{}, invalidGrant
+		}
+		return domain.OIDCTokens{}, err
+	}
+	// Адреса-заглушки аккаунтов Telegram приложениям не отдаём
+	if !mailable(user.Email) {
+		user.Email = ""
+	}
+
+	// Отдельная сессия: пользователь видит приложение в списке сессий и может отозвать его доступ
+	sessionID, err := uuid.NewRandom()
+	if err != nil {
+		return domain.OIDCTokens{}, err
+	}
+	accessToken, err := authJWT.CreateClientAccessToken(*user, sessionID, client.ClientID, grant.Scopes, a.Settings)
+	if err != nil {
+		return domain.OIDCTokens{}, err
+	}
+	idToken, err := authJWT.CreateIDToken(*user, client.ClientID, grant.Nonce, grant.AuthTime, grant.Scopes, a.Settings)
+	if err != nil {
+		return domain.OIDCTokens{}, err
+	}
+
+	now := time.Now()
+	// Refresh токена у приложения нет, сессия заканчивается вместе с access токеном
+	err = a.Casher.SetSessionTTL(ctx, authRedis.Session{
+		ID:         sessionID.String(),
+		UserID:     user.ID.String(),
+		Email:      user.Email,
+		UserAgent:  client.Name,
+		IP:         clientinfo.IP(ctx),
+		CreatedAt:  now,
+		LastUsedAt: now,
+	}, a.Settings.AccessTTL)
+	if err != nil {
+		a.Logger.Error(op, slog.String(op, err.Error()))
+		return domain.OIDCTokens{}, err
+	}
+
+	return domain.OIDCTokens{
+		AccessToken: accessToken,
+		IDToken:     idToken,
+		ExpiresIn:   a.Settings.AccessTTL,
+		Scopes:      grant.Scopes,
+	}, nil
+}
+
+// OIDCUserInfo returns claims of the user allowed by scopes of access token issued to the client
+func (a *Auth) OIDCUserInfo(ctx context.Context, accessToken string) (claims map[string]any, err error) {
+	parsed, session, err := a.verifyAccessToken(ctx, accessToken)
+	if err != nil {
+		return nil, err
+	}
+	// Собственные токены сервиса выдаются без согласия пользователя на scopes
+	scopes := parsed.Scopes()
+	if parsed.ClientID == "" || !slices.Contains(scopes, domain.ScopeOpenID) {
+		return nil, domain.ErrForbidden
+	}
+	user, err := a.sessionUser(session)
+	if err != nil {
+		return nil, err
+	}
+
+	if !mailable(user.Email) {
+		user.Email = ""
+	}
+	return authJWT.UserClaims(*user, scopes), nil
+}
@@ -0,0 +1,460 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/SeiFlow-3P2/auth_service/internal/domain"
+	"github.com/SeiFlow-3P2/auth_service/pkg/authRedis"
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+const (
+	passkeyRegistration = "registration"
+	passkeyLogin        = "login"
+	passkeyMFA          = "mfa"
+)
+
+// passkeySession is state of WebAuthn ceremony between begin and finish calls
+type passkeySession struct {
+	Purpose string               `json:"purpose"`
+	UserID  string               `json:"user_id,omitempty"`
+	Data    webauthn.SessionData `json:"data"`
+}
+
+// webauthnUser adapts domain.User to webauthn.User
+type webauthnUser struct {
+	user        *domain.User
+	credentials []domain.WebAuthnCredential
+}
+
+func (u webauthnUser) WebAuthnID() []byte {
+	return u.user.ID[:]
+}
+
+func (u webauthnUser) WebAuthnName() string {
+	return u.user.Email
+}
+
+func (u webauthnUser) WebAuthnDisplayName() string {
+	if u.user.Username != "" {
+		return u.user.Username
+	}
+	return u.user.Email
+}
+
+func (u webauthnUser) WebAuthnCredentials() []webauthn.Credential {
+	credentials := make([]webauthn.Credential, 0, len(u.credentials))
+	for _, c := range u.credentials {
+		var transports []protocol.AuthenticatorTransport
+		if c.Transports != "" {
+			for _, t := range strings.Split(c.Transports, ",") {
+				transports = append(transports, protocol.AuthenticatorTransport(t))
+			}
+		}
+		credentials = append(credentials, webauthn.Credential{
+			ID:              c.CredentialID,
+			PublicKey:       c.PublicKey,
+			AttestationType: c.AttestationType,
+			Transport:       transports,
+			Flags: webauthn.CredentialFlags{
+				UserVerified:   c.UserVerified,
+				BackupEligible: c.BackupEligible,
+				BackupState:    c.BackupState,
+			},
+			Authenticator: webauthn.Authenticator{AAGUID: c.AAGUID, SignCount: c.SignCount},
+		})
+	}
+	return credentials
+}
+
+// credential returns stored credential by id from authenticator
+func (u webauthnUser) credential(id []byte) *domain.WebAuthnCredential {
+	for i := range u.credentials {
+		if bytes.Equal(u.credentials[i].CredentialID, id) {
+			return &u.credentials[i]
+		}
+	}
+	return nil
+}
+
+// webauthnUserByID loads user with his passkeys
+func (a *Auth) webauthnUserByID(userID uuid.UUID) (*webauthnUser, error) {
+	user, err := a.AuthDB.GetUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	credentials, err := a.AuthDB.GetWebAuthnCredentials(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &webauthnUser{user: user, credentials: credentials}, nil
+}
+
+// savePasskeySession stores ceremony state and returns its id for the client
+func (a *Auth) savePasskeySession(ctx context.Context, session passkeySession) (string, error) {
+	id, err := randomToken()
+	if err != nil {
+		return "", err
+	}
+
+	data, err := json.Marshal(session)
+	if err != nil {
+		return "", err
+	}
+	if err := a.Casher.SetWebAuthnSession(ctx, hashToken(id), data, a.Settings.WebAuthnTimeout); err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+// takePasskeySession returns ceremony state, it can be used only once
+func (a *Auth) takePasskeySession(ctx context.Context, id string, purpose string) (*passkeySession, error) {
+	data, err := a.Casher.TakeWebAuthnSession(ctx, hashToken(id))
+	if err != nil {
+		if errors.Is(err, authRedis.ErrWebAuthnSessionNotFound) {
+			return nil, domain.ErrInvalidToken
+		}
+		return nil, err
+	}
+	var session passkeySession
+	if err := json.Unmarshal(data, &session); err != nil {
+		return nil, err
+	}
+	if session.Purpose != purpose {
+		return nil, domain.ErrInvalidToken
+	}
+	return &session, nil
+}
+
+// BeginPasskeyRegistration returns options for navigator.credentials.create() of the current user.
+// Passkey is both a login method and a second factor, so after ReauthWindow since login
+// the current password and MFA code are required.
+func (a *Auth) BeginPasskeyRegistration(ctx context.Context, accessToken string, password []byte, code string) (sessionID string, options []byte, err error) {
+	op := "Auth_Service_BeginPasskeyRegistration: "
+
+	if a.WebAuthn == nil {
+		return "", nil, domain.ErrWebAuthnDisabled
+	}
+	session, user, err := a.currentUser(ctx, accessToken)
+	if err != nil {
+		return "", nil, err
+	}
+	if err := a.reauthenticate(ctx, session, user, password, code); err != nil {
+		return "", nil, err
+	}
+	credentials, err := a.AuthDB.GetWebAuthnCredentials(user.ID)
+	if err != nil {
+		return "", nil, err
+	}
+	wUser := webauthnUser{user: user, credentials: credentials}
+
+	// Один аутентификатор нельзя зарегистрировать дважды
+	creation, data, err := a.WebAuthn.BeginRegistration(wUser,
+		webauthn.WithExclusions(webauthn.Credentials(wUser.WebAuthnCredentials()).CredentialDescriptors()),
+		webauthn.WithResidentKeyRequirement(protocol.ResidentKeyRequirementRequired),
+	)
+	if err != nil {
+		a.Logger.Error(op, slog.String(op, err.Error()))
+		return "", nil, err
+	}
+
+	sessionID, err = a.savePasskeySession(ctx, passkeySession{Purpose: passkeyRegistration, UserID: user.ID.String(), Data: *data})
+	if err != nil {
+		return "", nil, err
+	}
+	options, err = json.Marshal(creation)
+	if err != nil {
+		return "", nil, err
+	}
+	return sessionID, options, nil
+}
+
+// FinishPasskeyRegistration checks attestation from the authenticator and saves new passkey
+func (a *Auth) FinishPasskeyRegistration(ctx context.Context, accessToken string, sessionID string, name string, credential []byte) (credentialID uuid.UUID, err error) {
+	op := "Auth_Service_FinishPasskeyRegistration: "
+
+	if a.WebAuthn == nil {
+		return uuid.Nil, domain.ErrWebAuthnDisabled
+	}
+	current, user, err := a.currentUser(ctx, accessToken)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	session, err := a.takePasskeySession(ctx, sessionID, passkeyRegistration)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if session.UserID != user.ID.String() {
+		return uuid.Nil, domain.ErrInvalidToken
+	}
+
+	parsed, err := protocol.ParseCredentialCreationResponseBytes(credential)
+	if err != nil {
+		a.Logger.Info(op, slog.String(op, err.Error()))
+		return uuid.Nil, domain.ErrInvalidPasskey
+	}
+	created, err := a.WebAuthn.CreateCredential(webauthnUser{user: user}, session.Data, parsed)
+	if err != nil {
+		a.Logger.Info(op, slog.String(op, err.Error()))
+		return uuid.Nil, domain.ErrInvalidPasskey
+	}
+
+	transports := make([]string, 0, len(created.Transport))
+	for _, t := range created.Transport {
+		transports = append(transports, string(t))
+	}
+	if name == "" {
+		name = "Passkey"
+	}
+	record := &domain.WebAuthnCredential{
+		UserID:          user.ID,
+		Name:            name,
+		CredentialID:    created.ID,
+		PublicKey:       created.PublicKey,
+		AttestationType: created.AttestationType,
+		Transports:      strings.Join(transports, ","),
+		AAGUID:          created.Authenticator.AAGUID,
+		SignCount:       created.Authenticator.SignCount,
+		UserVerified:    created.Flags.UserVerified,
+		BackupEligible:  created.Flags.BackupEligible,
+		BackupState:     created.Flags.BackupState,
+	}
+	if err := a.AuthDB.CreateWebAuthnCredential(record); err != nil {
+		a.Logger.Error(op, slog.String(op, err.Error()))
+		return uuid.Nil, err
+	}
+
+	a.securityEvent(ctx, user.ID, domain.EventPasskeyAdded, current.ID, record.Name)
+	return record.ID, nil
+}
+
+// ListPasskeys returns passkeys of the current user
+func (a *Auth) ListPasskeys(ctx context.Context, accessToken string) (passkeys []domain.WebAuthnCredential, err error) {
+	_, user, err := a.currentUser(ctx, accessToken)
+	if err != nil {
+		return nil, err
+	}
+	return a.AuthDB.GetWebAuthnCredentials(user.ID)
+}
+
+// DeletePasskey removes passkey of the current user. Like registration it requires recent
+// login or the password and MFA code. The last way to log in can't be removed.
+func (a *Auth) DeletePasskey(ctx context.Context, accessToken string, passkeyID uuid.UUID, password []byte, code string) (err error) {
+	op := "Auth_Service_DeletePasskey: "
+
+	session, user, err := a.currentUser(ctx, accessToken)
+	if err != nil {
+		return err
+	}
+	passkeys, err := a.AuthDB.GetWebAuthnCredentials(user.ID)
+	if err != nil {
+		return err
+	}
+	var passkey *domain.WebAuthnCredential
+	for i := range passkeys {
+		if passkeys[i].ID == passkeyID {
+			passkey = &passkeys[i]
+		}
+	}
+	if passkey == nil {
+		return domain.ErrPasskeyNotFound
+	}
+	if err := a.reauthenticate(ctx, session, user, password, code); err != nil {
+		return err
+	}
+
+	identities, err := a.AuthDB.GetIdentities(user.ID)
+	if err != nil {
+		return err
+	}
+	methods := len(identities) + len(passkeys)
+	if len(user.PasswordHash) > 0 {
+		methods++
+	}
+	if methods <= 1 {
+		return domain.ErrLastLoginMethod
+	}
+
+	if err := a.AuthDB.DeleteWebAuthnCredential(user.ID, passkey.ID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domain.ErrPasskeyNotFound
+		}
+		a.Logger.Error(op, slog.String(op, err.Error()))
+		return err
+	}
+
+	a.securityEvent(ctx, user.ID, domain.EventPasskeyRemoved, session.ID, passkey.Name)
+	return nil
+}
+
+// BeginPasskeyLogin returns options for navigator.credentials.get().
+// With mfaChallenge passkey is the second factor of the user who passed the password,
+// otherwise any passkey of this site may be chosen by the user (discoverable login).
+func (a *Auth) BeginPasskeyLogin(ctx context.Context, mfaChallenge string) (sessionID string, options []byte, err error) {
+	op := "Auth_Service_BeginPasskeyLogin: "
+
+	if a.WebAuthn == nil {
+		return "", nil, domain.ErrWebAuthnDisabled
+	}
+
+	var (
+		assertion *protocol.CredentialAssertion
+		data      *webauthn.SessionData
+		session   = passkeySession{Purpose: passkeyLogin}
+	)
+	if mfaChallenge != "" {
+		user, _, err := a.mfaChallengeUser(ctx, hashToken(mfaChallenge))
+		if err != nil {
+			return "", nil, err
+		}
+		wUser, err := a.webauthnUserByID(user.ID)
+		if err != nil {
+			return "", nil, err
+		}
+		if len(wUser.credentials) == 0 {
+			return "", nil, domain.ErrInvalidPasskey
+		}
+		assertion, data, err = a.WebAuthn.BeginLogin(wUser)
+		if err != nil {
+			a.Logger.Error(op, slog.String(op, err.Error()))
+			return "", nil, err
+		}
+		session = passkeySession{Purpose: passkeyMFA, UserID: user.ID.String()}
+	} else {
+		// Без пароля passkey должен сам проверить пользователя (PIN, биометрия)
+		assertion, data, err = a.WebAuthn.BeginDiscoverableLogin(webauthn.WithUserVerification(protocol.VerificationRequired))
+		if err != nil {
+			a.Logger.Error(op, slog.String(op, err.Error()))
+			return "", nil, err
+		}
+	}
+	session.Data = *data
+
+	sessionID, err = a.savePasskeySession(ctx, session)
+	if err != nil {
+		return "", nil, err
+	}
+	options, err = json.Marshal(assertion)
+	if err != nil {
+		return "", nil, err
+	}
+	return sessionID, options, nil
+}
+
+// checkPasskeyUsage rejects cloned authenticators and stores new sign counter
+func (a *Auth) checkPasskeyUsage(ctx context.Context, stored *domain.WebAuthnCredential, used *webauthn.Credential) error {
+	if used.Authenticator.CloneWarning {
+		// Счётчик подписей не вырос — ключ, возможно, скопирован
+		a.securityEvent(ctx, stored.UserID, domain.EventPasskeyCloned, "", stored.ID.String())
+		return domain.ErrInvalidPasskey
+	}
+	return a.AuthDB.UpdateWebAuthnCredentialUsage(stored.ID, used.Authenticator.SignCount, used.Flags.BackupState, time.Now())
+}
+
+// LoginByPasskey completes passwordless login started with BeginPasskeyLogin.
+// Passkey with user verification is already two factors, so TOTP is not asked.
+func (a *Auth) LoginByPasskey(ctx context.Context, sessionID string, credential []byte) (userID uuid.UUID, accessToken string, refreshToken string, message string, err error) {
+	op := "Auth_Service_LoginByPasskey: "
+
+	if a.WebAuthn == nil {
+		return uuid.Nil, "", "", "", domain.ErrWebAuthnDisabled
+	}
+	session, err := a.takePasskeySession(ctx, sessionID, passkeyLogin)
+	if err != nil {
+		return uuid.Nil, "", "", "", err
+	}
+	parsed, err := protocol.ParseCredentialRequestResponseBytes(credential)
+	if err != nil {
+		a.Logger.Info(op, slog.String(op, err.Error()))
+		return uuid.Nil, "", "", "", domain.ErrInvalidPasskey
+	}
+
+	var wUser *webauthnUser
+	handler := func(rawID, userHandle []byte) (webauthn.User, error) {
+		id, err := uuid.FromBytes(userHandle)
+		if err != nil {
+			return nil, domain.ErrInvalidPasskey
+		}
+		wUser, err = a.webauthnUserByID(id)
+		if err != nil {
+			return nil, err
+		}
+		return wUser, nil
+	}
+	_, used, err := a.WebAuthn.ValidatePasskeyLogin(handler, session.Data, parsed)
+	if err != nil {
+		// Сюда же попадает passkey удалённого пользователя
+		a.Logger.Info(op, slog.String(op, err.Error()))
+		return uuid.Nil, "", "", "", domain.ErrInvalidPasskey
+	}
+
+	stored := wUser.credential(used.ID)
+	if stored == nil {
+		return uuid.Nil, "", "", "", domain.ErrInvalidPasskey
+	}
+	if err := a.checkPasskeyUsage(ctx, stored, used); err != nil {
+		return uuid.Nil, "", "", "", err
+	}
+	if a.Settings.RequireVerifiedEmail && !wUser.user.EmailVerified() {
+		return uuid.Nil, "", "", "", domain.ErrEmailNotVerified
+	}
+
+	tokens, err := a.issueTokens(ctx, wUser.user)
+	if err != nil {
+		return uuid.Nil, "", "", "", err
+	}
+	return wUser.user.ID, tokens.AccessToken, tokens.RefreshToken, "", nil
+}
+
+// VerifyMFAPasskey completes login started with password by passkey of the user
+func (a *Auth) VerifyMFAPasskey(ctx context.Context, challenge string, sessionID string, credential []byte) (userID uuid.UUID, accessToken string, refreshToken string, err error) {
+	op := "Auth_Service_VerifyMFAPasskey: "
+
+	if a.WebAuthn == nil {
+		return uuid.Nil, "", "", domain.ErrWebAuthnDisabled
+	}
+	key := hashToken(challenge)
+	user, _, err := a.mfaChallengeUser(ctx, key)
+	if err != nil {
+		return uuid.Nil, "", "", err
+	}
+	session, err := a.takePasskeySession(ctx, sessionID, passkeyMFA)
+	if err != nil {
+		return uuid.Nil, "", "", err
+	}
+	if session.UserID != user.ID.String() {
+		return uuid.Nil, "", "", domain.ErrInvalidToken
+	}
+
+	parsed, err := protocol.ParseCredentialRequestResponseBytes(credential)
+	if err != nil {
+		a.Logger.Info(op, slog.String(op, err.Error()))
+		return uuid.Nil, "", "", domain.ErrInvalidPasskey
+	}
+	wUser, err := a.webauthnUserByID(user.ID)
+	if err != nil {
+		return uuid.Nil, "", "", err
+	}
+	used, err := a.WebAuthn.ValidateLogin(wUser, session.Data, parsed)
+	if err != nil {
+		a.Logger.Info(op, slog.String(op, err.Error()))
+		return uuid.Nil, "", "", domain.ErrInvalidPasskey
+	}
+	stored := wUser.credential(used.ID)
+	if stored == nil {
+		return uuid.Nil, "", "", domain.ErrInvalidPasskey
+	}
+	if err := a.checkPasskeyUsage(ctx, stored, used); err != nil {
+		return uuid.Nil, "", "", err
+	}
+
+	return a.finishMFAChallenge(ctx, key, wUser.user)
+}
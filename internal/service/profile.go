@@ -0,0 +1,262 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/SeiFlow-3P2/auth_service/internal/domain"
+	"github.com/SeiFlow-3P2/auth_service/pkg/authMail"
+	"github.com/SeiFlow-3P2/auth_service/pkg/authRedis"
+	verfic "github.com/SeiFlow-3P2/auth_service/pkg/utils/verifications"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+// currentUser authenticates access token and returns its session and user
+func (a *Auth) currentUser(ctx context.Context, accessToken string) (*authRedis.Session, *domain.User, error) {
+	session, err := a.authenticate(ctx, accessToken)
+	if err != nil {
+		return nil, nil, err
+	}
+	user, err := a.sessionUser(session)
+	if err != nil {
+		return nil, nil, err
+	}
+	return session, user, nil
+}
+
+// sessionUser returns the owner of the session
+func (a *Auth) sessionUser(session *authRedis.Session) (*domain.User, error) {
+	userID, err := uuid.Parse(session.UserID)
+	if err != nil {
+		return nil, domain.ErrUnauthenticated
+	}
+	user, err := a.AuthDB.GetUser(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrUnauthenticated
+		}
+		return nil, err
+	}
+	return user, nil
+}
+
+// checkPassword checks current password of the user. Users registered through
+// oauth have no password and have to set it with password reset first.
+func (a *Auth) checkPassword(user *domain.User, password []byte) error {
+	if len(user.PasswordHash) == 0 {
+		return domain.ErrInvalidPassword
+	}
+	if _, err := a.Hasher.Verify(password, user.PasswordHash); err != nil {
+		return domain.ErrInvalidPassword
+	}
+	return nil
+}
+
+// reauthenticate checks that the user has just logged in or asks password again and MFA code
+// if it is enabled. Users without password have to log in again after ReauthWindow.
+func (a *Auth) reauthenticate(ctx context.Context, session *authRedis.Session, user *domain.User, password []byte, code string) error {
+	if time.Since(session.CreatedAt) <= a.Settings.ReauthWindow {
+		return nil
+	}
+	if len(user.PasswordHash) == 0 || len(password) == 0 {
+		return domain.ErrReauthRequired
+	}
+	if err := a.checkPassword(user, password); err != nil {
+		return err
+	}
+
+	mfa, err := a.userMFA(user.ID)
+	if err != nil {
+		return err
+	}
+	if !mfa.Enabled() {
+		return nil
+	}
+	if code == "" {
+		return domain.ErrReauthRequired
+	}
+	return a.checkMFACodeThrottled(ctx, user, mfa, code)
+}
+
+// ChangePassword sets new password and revokes all other sessions of the user
+func (a *Auth) ChangePassword(ctx context.Context, accessToken string, currentPassword []byte, newPassword []byte) (err error) {
+	op := "Auth_Service_ChangePassword: "
+
+	session, user, err := a.currentUser(ctx, accessToken)
+	if err != nil {
+		return err
+	}
+	if err := a.checkPassword(user, currentPassword); err != nil {
+		return err
+	}
+
+	passwordHash, err := a.Hasher.Hash(newPassword)
+	if err != nil {
+		return err
+	}
+	if err := a.AuthDB.ChangePassword(user.ID, passwordHash); err != nil {
+		a.Logger.Error(op, slog.String(op, err.Error()))
+		return err
+	}
+
+	if err := a.revokeOtherSessions(ctx, session); err != nil {
+		a.Logger.Error(op, slog.String(op, err.Error()))
+		return err
+	}
+	a.securityEvent(ctx, user.ID, domain.EventPasswordChanged, session.ID, "")
+	return nil
+}
+
+// ChangeEmail changes email of the user and sends verification to the new one.
+// Other sessions are revoked, current session is moved to the new email.
+func (a *Auth) ChangeEmail(ctx context.Context, accessToken string, password []byte, newEmail string) (err error) {
+	op := "Auth_Service_ChangeEmail: "
+
+	valid, err := verfic.VerifyEmail(newEmail)
+	// Адреса-заглушки принадлежат аккаунтам Telegram
+	if err != nil || !valid || !mailable(newEmail) {
+		return errors.New("invalid email")
+	}
+
+	session, user, err := a.currentUser(ctx, accessToken)
+	if err != nil {
+		return err
+	}
+	if err := a.checkPassword(user, password); err != nil {
+		return err
+	}
+	if strings.EqualFold(user.Email, newEmail) {
+		return nil
+	}
+
+	_, err = a.AuthDB.GetUserByEmail(newEmail)
+	if err == nil {
+		return domain.ErrEmailTaken
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	oldEmail := user.Email
+	if err := a.AuthDB.ChangeEmail(user.ID, newEmail); err != nil {
+		a.Logger.Error(op, slog.String(op, err.Error()))
+		return err
+	}
+	user.Email = newEmail
+	user.VerifiedAt = nil
+
+	if err := a.revokeOtherSessions(ctx, session); err != nil {
+		a.Logger.Error(op, slog.String(op, err.Error()))
+		return err
+	}
+	session.Email = newEmail
+	if err := a.Casher.SetSession(ctx, *session); err != nil {
+		a.Logger.Error(op, slog.String(op, err.Error()))
+		return err
+	}
+
+	if err := a.sendVerification(ctx, user); err != nil {
+		a.Logger.Error(op, slog.String(op, err.Error()))
+	}
+	// Предупреждаем старый адрес на случай, если аккаунт угнали
+	err = a.sendMail(ctx, oldEmail, authMail.TemplateEmailChanged, map[string]any{
+		"Username": user.Username,
+		"NewEmail": newEmail,
+	})
+	if err != nil {
+		a.Logger.Error(op, slog.String(op, err.Error()))
+	}
+
+	a.securityEvent(ctx, user.ID, domain.EventEmailChanged, session.ID, oldEmail+" -> "+newEmail)
+	return nil
+}
+
+// UpdateProfile changes username, photo and telegram id of the user and returns updated user
+func (a *Auth) UpdateProfile(ctx context.Context, accessToken string, update domain.ProfileUpdate) (user *domain.User, err error) {
+	op := "Auth_Service_UpdateProfile: "
+
+	session, user, err := a.currentUser(ctx, accessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	var changed []string
+	if update.Username != nil && *update.Username != user.Username {
+		if *update.Username == "" {
+			return nil, errors.New("empty username")
+		}
+		_, err := a.AuthDB.GetUserByUsername(*update.Username)
+		if err == nil {
+			return nil, domain.ErrUsernameTaken
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		if err := a.AuthDB.ChangeUsername(user.ID, *update.Username); err != nil {
+			a.Logger.Error(op, slog.String(op, err.Error()))
+			return nil, err
+		}
+		changed = append(changed, "username")
+	}
+	if update.PhotoUrl != nil && *update.PhotoUrl != user.PhotoUrl {
+		if err := a.AuthDB.ChangePhoto(user.ID, *update.PhotoUrl); err != nil {
+			a.Logger.Error(op, slog.String(op, err.Error()))
+			return nil, err
+		}
+		changed = append(changed, "photo_url")
+	}
+	if update.TelegramID != nil && *update.TelegramID != user.TelegramId {
+		telegram, err := a.telegramIdentity(user)
+		if err != nil {
+			return nil, err
+		}
+		if err := a.AuthDB.ChangeTelegramId(user.ID, *update.TelegramID); err != nil {
+			a.Logger.Error(op, slog.String(op, err.Error()))
+			return nil, err
+		}
+		changed = append(changed, "telegram_id")
+		if telegram != nil {
+			a.securityEvent(ctx, user.ID, domain.EventIdentityUnlinked, session.ID, telegram.Provider+":"+telegram.Subject)
+		}
+	}
+
+	if len(changed) == 0 {
+		return user, nil
+	}
+	a.securityEvent(ctx, user.ID, domain.EventProfileUpdated, session.ID, strings.Join(changed, ","))
+	return a.AuthDB.GetUser(user.ID)
+}
+
+// telegramIdentity returns linked telegram account that is removed when telegram id changes.
+// Returns ErrLastLoginMethod if it is the only way for the user to log in.
+func (a *Auth) telegramIdentity(user *domain.User) (*domain.Identity, error) {
+	identities, err := a.AuthDB.GetIdentities(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	var telegram *domain.Identity
+	for i := range identities {
+		if identities[i].Provider == domain.ProviderTelegram {
+			telegram = &identities[i]
+		}
+	}
+	if telegram == nil {
+		return nil, nil
+	}
+
+	passkeys, err := a.AuthDB.GetWebAuthnCredentials(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	methods := len(identities) + len(passkeys)
+	if len(user.PasswordHash) > 0 {
+		methods++
+	}
+	if methods <= 1 {
+		return nil, domain.ErrLastLoginMethod
+	}
+	return telegram, nil
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"github.com/SeiFlow-3P2/auth_service/internal/domain"
+	"github.com/SeiFlow-3P2/auth_service/pkg/authMail"
+	"github.com/SeiFlow-3P2/auth_service/pkg/authRedis"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"log/slog"
+	"net/url"
+	"time"
+)
+
+// hashToken returns hash under which one-time token is stored in casher, the token itself is only given to the client
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// RequestPasswordReset sends password reset link to the email. It succeeds for unknown
+// emails too and doesn't fail on delivery errors, so the response doesn't reveal registered emails.
+func (a *Auth) RequestPasswordReset(ctx context.Context, email string) (err error) {
+	op := "Auth_Service_RequestPasswordReset: "
+
+	user, err := a.AuthDB.GetUserByEmail(email)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return err
+	}
+	token := base64.RawURLEncoding.EncodeToString(raw)
+
+	if err := a.Casher.SetPasswordReset(ctx, user.ID.String(), hashToken(token), a.Settings.PasswordResetTTL); err != nil {
+		a.Logger.Error(op, slog.String(op, err.Error()))
+		return nil
+	}
+
+	link := a.Settings.PasswordResetURL + url.QueryEscape(token)
+	err = a.sendMail(ctx, user.Email, authMail.TemplatePasswordReset, map[string]any{
+		"Username": user.Username,
+		"Link":     link,
+		"TTL":      a.Settings.PasswordResetTTL,
+	})
+	if err != nil {
+		a.Logger.Error(op, slog.String(op, err.Error()))
+	}
+	return nil
+}
+
+// ResetPassword sets new password by reset token and revokes all sessions of the user
+func (a *Auth) ResetPassword(ctx context.Context, token string, password []byte) (err error) {
+	op := "Auth_Service_ResetPassword: "
+
+	id, err := a.Casher.TakePasswordReset(ctx, hashToken(token))
+	if err != nil {
+		if errors.Is(err, authRedis.ErrResetTokenNotFound) {
+			return domain.ErrInvalidToken
+		}
+		return err
+	}
+	userID, err := uuid.Parse(id)
+	if err != nil {
+		return domain.ErrInvalidToken
+	}
+
+	user, err := a.AuthDB.GetUser(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domain.ErrInvalidToken
+		}
+		return err
+	}
+
+	passwordHash, err := a.Hasher.Hash(password)
+	if err != nil {
+		return err
+	}
+	if err := a.AuthDB.ChangePassword(user.ID, passwordHash); err != nil {
+		a.Logger.Error(op, slog.String(op, err.Error()))
+		return err
+	}
+
+	// Ссылка пришла на почту, значит владение email подтверждено
+	if !user.EmailVerified() {
+		if err := a.AuthDB.SetEmailVerified(user.ID, time.Now()); err != nil {
+			a.Logger.Error(op, slog.String(op, err.Error()))
+		}
+	}
+
+	if err := a.Casher.BlockUserSessions(ctx, user.ID.String()); err != nil {
+		a.Logger.Error(op, slog.String(op, err.Error()))
+		return err
+	}
+
+	a.securityEvent(ctx, user.ID, domain.EventPasswordReset, "", "")
+	return nil
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/SeiFlow-3P2/auth_service/internal/domain"
+	"github.com/SeiFlow-3P2/auth_service/pkg/authJWT"
+	"github.com/SeiFlow-3P2/auth_service/pkg/authRedis"
+	"log/slog"
+)
+
+// authenticate checks first-party access token and returns the session it belongs to
+func (a *Auth) authenticate(ctx context.Context, accessToken string) (*authRedis.Session, error) {
+	claims, session, err := a.verifyAccessToken(ctx, accessToken)
+	if err != nil {
+		return nil, err
+	}
+	// Токены сторонних приложений годятся только для userinfo, иначе клиент
+	// получил бы весь аккаунт пользователя
+	if claims.ClientID != "" {
+		return nil, domain.ErrUnauthenticated
+	}
+	return session, nil
+}
+
+// verifyAccessToken checks signature of access token and that its session is alive.
+// Tokens of OIDC clients are accepted too, callers decide what they may do.
+func (a *Auth) verifyAccessToken(ctx context.Context, accessToken string) (*authJWT.Claims, *authRedis.Session, error) {
+	op := "Auth_Service_verifyAccessToken: "
+
+	claims, err := authJWT.ParseToken(accessToken, authJWT.TokenTypeAccess, a.Settings)
+	if err != nil {
+		a.Logger.Info(op, slog.String(op, err.Error()))
+		return nil, nil, domain.ErrUnauthenticated
+	}
+	// У сервисов нет ни сессии, ни пользователя
+	if claims.IsService() {
+		return nil, nil, domain.ErrUnauthenticated
+	}
+
+	// Отозванная сессия делает недействительными и её access токены
+	session, err := a.Casher.GetSession(ctx, claims.SessionID)
+	if err != nil {
+		if errors.Is(err, authRedis.ErrSessionNotFound) {
+			return nil, nil, domain.ErrUnauthenticated
+		}
+		return nil, nil, err
+	}
+	if session.UserID != claims.Subject {
+		return nil, nil, domain.ErrUnauthenticated
+	}
+	return claims, session, nil
+}
+
+func (a *Auth) ListSessions(ctx context.Context, accessToken string) (sessions []authRedis.Session, currentSessionID string, err error) {
+	current, err := a.authenticate(ctx, accessToken)
+	if err != nil {
+		return nil, "", err
+	}
+
+	sessions, err = a.Casher.UserSessions(ctx, current.UserID)
+	if err != nil {
+		return nil, "", err
+	}
+	return sessions, current.ID, nil
+}
+
+func (a *Auth) RevokeSession(ctx context.Context, accessToken string, sessionID string) (err error) {
+	current, err := a.authenticate(ctx, accessToken)
+	if err != nil {
+		return err
+	}
+
+	session, err := a.Casher.GetSession(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, authRedis.ErrSessionNotFound) {
+			return domain.ErrSessionNotFound
+		}
+		return err
+	}
+	// Чужие сессии не отличаем от несуществующих
+	if session.UserID != current.UserID {
+		return domain.ErrSessionNotFound
+	}
+
+	return a.Casher.BlockSession(ctx, session.UserID, session.ID)
+}
+
+func (a *Auth) RevokeAllSessions(ctx context.Context, accessToken string, keepCurrent bool) (err error) {
+	current, err := a.authenticate(ctx, accessToken)
+	if err != nil {
+		return err
+	}
+
+	if !keepCurrent {
+		return a.Casher.BlockUserSessions(ctx, current.UserID)
+	}
+	return a.revokeOtherSessions(ctx, current)
+}
+
+// revokeOtherSessions revokes all sessions of the user except current
+func (a *Auth) revokeOtherSessions(ctx context.Context, current *authRedis.Session) error {
+	sessions, err := a.Casher.UserSessions(ctx, current.UserID)
+	if err != nil {
+		return err
+	}
+	for _, session := range sessions {
+		if session.ID == current.ID {
+			continue
+		}
+		if err := a.Casher.BlockSession(ctx, session.UserID, session.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
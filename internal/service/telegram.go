@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/SeiFlow-3P2/auth_service/internal/domain"
+	"github.com/SeiFlow-3P2/auth_service/pkg/authTelegram"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// telegramEmailDomain у аккаунтов Telegram нет email, а в users он обязателен и уникален.
+// Домен .invalid зарезервирован (RFC 2606), письма на такие адреса не отправляются.
+const telegramEmailDomain = "@telegram.invalid"
+
+// telegramEmail returns placeholder email of the user created through Telegram.
+// Suffix makes it unique when the plain address is already taken.
+func telegramEmail(id int64, suffix string) string {
+	if suffix != "" {
+		return fmt.Sprintf("tg%d.%s%s", id, suffix, telegramEmailDomain)
+	}
+	return fmt.Sprintf("tg%d%s", id, telegramEmailDomain)
+}
+
+// telegramUser verifies Telegram Login Widget data. Every payload is accepted only once.
+func (a *Auth) telegramUser(ctx context.Context, fields map[string]string) (*authTelegram.User, error) {
+	op := "Auth_Service_telegramUser: "
+
+	if a.Settings.TelegramBotToken == "" {
+		return nil, domain.ErrTelegramDisabled
+	}
+	tgUser, err := authTelegram.Verify(fields, a.Settings.TelegramBotToken, a.Settings.TelegramAuthMaxAge, time.Now())
+	if err != nil {
+		a.Logger.Info(op, slog.String(op, err.Error()))
+		return nil, domain.ErrInvalidTelegram
+	}
+
+	fresh, err := a.Casher.UseOnce(ctx, "telegram:"+fields[authTelegram.FieldHash], a.Settings.TelegramAuthMaxAge+time.Minute)
+	if err != nil {
+		return nil, err
+	}
+	if !fresh {
+		return nil, domain.ErrInvalidTelegram
+	}
+	return tgUser, nil
+}
+
+// createTelegramUser registers new user with confirmed telegram id. The account
+// is found later only through user_identities, never by its placeholder email.
+func (a *Auth) createTelegramUser(ctx context.Context, tgUser *authTelegram.User) (*domain.User, error) {
+	username := tgUser.Username
+	if username == "" {
+		username = fmt.Sprintf("tg%d", tgUser.ID)
+	}
+	email := telegramEmail(tgUser.ID, "")
+
+	err := a.AuthDB.CreateUser(username, email, tgUser.PhotoURL, uint(tgUser.ID), nil)
+	if err != nil {
+		// Имя или адрес уже заняты (например, аккаунтом, от которого Telegram отвязали),
+		// пробуем ещё раз с уникальными
+		username = fmt.Sprintf("%s_tg%d", username, tgUser.ID)
+		email = telegramEmail(tgUser.ID, strings.ReplaceAll(uuid.NewString(), "-", "")[:12])
+		if err := a.AuthDB.CreateUser(username, email, tgUser.PhotoURL, uint(tgUser.ID), nil); err != nil {
+			return nil, err
+		}
+	}
+	user, err := a.AuthDB.GetUserByEmail(email)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := a.AuthDB.SetTelegramVerified(user.ID, uint(tgUser.ID)); err != nil {
+		return nil, err
+	}
+	user.TelegramId, user.TelegramVerified = uint(tgUser.ID), true
+	if _, err := a.linkIdentity(ctx, user, domain.ProviderTelegram, strconv.FormatInt(tgUser.ID, 10), "", ""); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// telegramAccount returns user who linked the telegram account or registers a new one
+func (a *Auth) telegramAccount(ctx context.Context, tgUser *authTelegram.User) (*domain.User, error) {
+	subject := strconv.FormatInt(tgUser.ID, 10)
+	identity, err := a.AuthDB.GetIdentity(domain.ProviderTelegram, subject)
+	if err == nil {
+		return a.AuthDB.GetUser(identity.UserID)
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	// Подтверждён до появления user_identities. Аккаунты, где telegram id
+	// просто указан при регистрации, не подходят.
+	user, err := a.AuthDB.GetUserByTelegramId(uint(tgUser.ID))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return a.createTelegramUser(ctx, tgUser)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if _, err := a.linkIdentity(ctx, user, domain.ProviderTelegram, subject, "", ""); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// LoginByTelegram logs in user with confirmed telegram account or registers a new one
+func (a *Auth) LoginByTelegram(ctx context.Context, fields map[string]string) (userID uuid.UUID, accessToken string, refreshToken string, message string, err error) {
+	op := "Auth_Service_LoginByTelegram: "
+
+	tgUser, err := a.telegramUser(ctx, fields)
+	if err != nil {
+		return uuid.Nil, "", "", "", err
+	}
+	user, err := a.telegramAccount(ctx, tgUser)
+	if err != nil {
+		a.Logger.Error(op, slog.String(op, err.Error()))
+		return uuid.Nil, "", "", "", err
+	}
+
+	tokens, err := a.loginTokens(ctx, user)
+	if err != nil {
+		return uuid.Nil, "", "", "", err
+	}
+	return user.ID, tokens.AccessToken, tokens.RefreshToken, "", nil
+}
+
+// linkTelegram confirms telegram account of the user
+func (a *Auth) linkTelegram(ctx context.Context, sessionID string, user *domain.User, fields map[string]string) (*domain.Identity, error) {
+	tgUser, err := a.telegramUser(ctx, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	owner, err := a.AuthDB.GetUserByTelegramId(uint(tgUser.ID))
+	if err == nil && owner.ID != user.ID {
+		return nil, domain.ErrIdentityLinked
+	}
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	identity, err := a.linkIdentity(ctx, user, domain.ProviderTelegram, strconv.FormatInt(tgUser.ID, 10), "", sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := a.AuthDB.SetTelegramVerified(user.ID, uint(tgUser.ID)); err != nil {
+		return nil, err
+	}
+	return identity, nil
+}
+
+// LinkTelegram confirms telegram account of the current user
+func (a *Auth) LinkTelegram(ctx context.Context, accessToken string, fields map[string]string) (err error) {
+	_, err = a.LinkIdentity(ctx, accessToken, domain.ProviderTelegram, "", fields)
+	return err
+}
+
+// mailable reports whether address can receive mail, placeholder emails can not
+func mailable(email string) bool {
+	return !strings.HasSuffix(strings.ToLower(strings.TrimRight(email, "> ")), telegramEmailDomain)
+}
@@ -0,0 +1,179 @@
+package service
+
+import (
+	"context"
+	"github.com/SeiFlow-3P2/auth_service/internal/domain"
+	"github.com/SeiFlow-3P2/auth_service/pkg/utils/clientinfo"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// throttleKey is a login throttling counter with its failures limit.
+// event is recorded to the user when the limit is reached.
+type throttleKey struct {
+	key         string
+	maxFailures int
+	event       string
+}
+
+func (a *Auth) throttleKeys(email string, ip string) []throttleKey {
+	keys := []throttleKey{{
+		key:         "email:" + strings.ToLower(email),
+		maxFailures: a.Settings.LoginThrottle.MaxEmailFailures,
+		event:       domain.EventLoginLockout,
+	}}
+	if ip != "" {
+		keys = append(keys, throttleKey{key: "ip:" + ip, maxFailures: a.Settings.LoginThrottle.MaxIPFailures})
+	}
+	return keys
+}
+
+// loginAttempt is login attempt reserved in a throttle counter before the password is checked
+type loginAttempt struct {
+	throttleKey
+	id    string
+	count int64
+}
+
+// reserveLogin counts login attempt for the email and the client IP before the password
+// is checked, so parallel attempts can't exceed the limits. Returns *domain.RetryError if
+// login is locked.
+func (a *Auth) reserveLogin(ctx context.Context, email string) ([]loginAttempt, error) {
+	var attempts []loginAttempt
+	var retryAfter time.Duration
+	for _, k := range a.throttleKeys(email, clientinfo.IP(ctx)) {
+		id, count, locked, err := a.Casher.LoginAttempt(ctx, k.key, a.Settings.LoginThrottle.Window, k.maxFailures)
+		if err != nil {
+			a.forgetAttempts(ctx, attempts)
+			return nil, err
+		}
+		if locked > 0 {
+			retryAfter = max(retryAfter, locked)
+			continue
+		}
+		attempts = append(attempts, loginAttempt{throttleKey: k, id: id, count: count})
+	}
+	if retryAfter > 0 {
+		// Отклонённый вход не должен занимать место в других счётчиках
+		a.forgetAttempts(ctx, attempts)
+		return nil, &domain.RetryError{RetryAfter: retryAfter}
+	}
+	return attempts, nil
+}
+
+// forgetAttempts removes attempts that are not failures from the counters
+func (a *Auth) forgetAttempts(ctx context.Context, attempts []loginAttempt) {
+	op := "Auth_Service_forgetAttempts: "
+
+	for _, attempt := range attempts {
+		if err := a.Casher.ForgetLoginAttempt(ctx, attempt.key, attempt.id); err != nil {
+			a.Logger.Error(op, slog.String(op, err.Error()))
+		}
+	}
+}
+
+// loginFailed locks further attempts when needed, failures are already counted in reserveLogin.
+// user is nil when nobody is registered with the email.
+func (a *Auth) loginFailed(ctx context.Context, user *domain.User, attempts []loginAttempt) {
+	op := "Auth_Service_loginFailed: "
+	cfg := a.Settings.LoginThrottle
+
+	for _, k := range attempts {
+		lock := loginDelay(cfg, int(k.count), k.maxFailures)
+		if lock == 0 {
+			continue
+		}
+		if err := a.Casher.LockLogin(ctx, k.key, lock); err != nil {
+			a.Logger.Error(op, slog.String(op, err.Error()))
+			continue
+		}
+
+		if int(k.count) >= k.maxFailures {
+			a.Logger.Warn(op+"login locked",
+				slog.String("key", k.key),
+				slog.Int64("failures", k.count),
+				slog.Duration("lockout", lock),
+			)
+			if user != nil && k.event != "" {
+				a.securityEvent(ctx, user.ID, k.event, "", strconv.FormatInt(k.count, 10)+" failed attempts")
+			}
+		}
+	}
+}
+
+// loginDelay returns how long to lock login after given number of failures
+func loginDelay(cfg domain.ThrottleSettings, failures int, maxFailures int) time.Duration {
+	if failures >= maxFailures {
+		return cfg.Lockout
+	}
+	if failures <= cfg.FreeAttempts {
+		return 0
+	}
+	// Задержка растёт вдвое с каждой попыткой, но не дольше блокировки
+	delay := cfg.BaseDelay
+	for i := cfg.FreeAttempts + 1; i < failures && delay < cfg.Lockout; i++ {
+		delay *= 2
+	}
+	return min(delay, cfg.Lockout)
+}
+
+// loginSucceeded resets failures of the email, IP counter keeps earlier failures
+func (a *Auth) loginSucceeded(ctx context.Context, email string, attempts []loginAttempt) {
+	op := "Auth_Service_loginSucceeded: "
+
+	if err := a.Casher.ClearLoginFailures(ctx, a.throttleKeys(email, "")[0].key); err != nil {
+		a.Logger.Error(op, slog.String(op, err.Error()))
+	}
+	// Удачная попытка не считается неудачей с этого IP
+	var ipAttempts []loginAttempt
+	for _, attempt := range attempts {
+		if strings.HasPrefix(attempt.key, "ip:") {
+			ipAttempts = append(ipAttempts, attempt)
+		}
+	}
+	a.forgetAttempts(ctx, ipAttempts)
+}
+
+// requireAdmin authenticates access token and checks the user is admin
+func (a *Auth) requireAdmin(ctx context.Context, accessToken string) (*domain.User, error) {
+	_, user, err := a.currentUser(ctx, accessToken)
+	if err != nil {
+		return nil, err
+	}
+	if !user.IsAdmin {
+		return nil, domain.ErrForbidden
+	}
+	return user, nil
+}
+
+// ClearLoginLockout removes failures and lockout of the email and/or IP, admin only
+func (a *Auth) ClearLoginLockout(ctx context.Context, accessToken string, email string, ip string) (err error) {
+	op := "Auth_Service_ClearLoginLockout: "
+
+	admin, err := a.requireAdmin(ctx, accessToken)
+	if err != nil {
+		return err
+	}
+
+	var keys []string
+	if email != "" {
+		keys = append(keys, a.throttleKeys(email, "")[0].key)
+	}
+	if ip != "" {
+		keys = append(keys, "ip:"+ip)
+	}
+	for _, key := range keys {
+		if err := a.Casher.ClearLoginFailures(ctx, key); err != nil {
+			a.Logger.Error(op, slog.String(op, err.Error()))
+			return err
+		}
+	}
+
+	a.Logger.Info(op+"login lockout cleared",
+		slog.String("admin_id", admin.ID.String()),
+		slog.String("keys", strings.Join(keys, ",")),
+	)
+	return nil
+}
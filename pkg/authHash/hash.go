@@ -0,0 +1,200 @@
+package authHash
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	AlgoArgon2id = "argon2id"
+	AlgoBcrypt   = "bcrypt"
+)
+
+var (
+	ErrMismatch      = errors.New("password does not match")
+	ErrInvalidHash   = errors.New("invalid password hash format")
+	ErrIncompatible  = errors.New("incompatible argon2 version")
+	ErrUnknownAlgo   = errors.New("unknown password hash algorithm")
+	ErrEmptyPassword = errors.New("empty password")
+)
+
+// Params holds the tunable parameters of the password hasher.
+type Params struct {
+	Algo        string
+	Memory      uint32 // KiB
+	Iterations  uint32
+	Parallelism uint8
+	SaltLength  uint32
+	KeyLength   uint32
+	BcryptCost  int
+}
+
+// DefaultParams returns OWASP recommended argon2id parameters.
+func DefaultParams() Params {
+	return Params{
+		Algo:        AlgoArgon2id,
+		Memory:      64 * 1024,
+		Iterations:  3,
+		Parallelism: 2,
+		SaltLength:  16,
+		KeyLength:   32,
+		BcryptCost:  bcrypt.DefaultCost,
+	}
+}
+
+// Hasher hashes and verifies passwords. Hashes are stored in PHC string
+// format so that parameters travel with the hash.
+type Hasher struct {
+	params Params
+}
+
+// NewHasher returns new password hasher
+func NewHasher(params Params) (*Hasher, error) {
+	switch params.Algo {
+	case AlgoArgon2id:
+		if params.Memory == 0 || params.Iterations == 0 || params.Parallelism == 0 ||
+			params.SaltLength == 0 || params.KeyLength == 0 {
+			return nil, errors.New("argon2id params must be positive")
+		}
+	case AlgoBcrypt:
+		if params.BcryptCost < bcrypt.MinCost || params.BcryptCost > bcrypt.MaxCost {
+			return nil, fmt.Errorf("bcrypt cost must be in [%d, %d]", bcrypt.MinCost, bcrypt.MaxCost)
+		}
+	default:
+		return nil, ErrUnknownAlgo
+	}
+	return &Hasher{params: params}, nil
+}
+
+// Hash returns encoded hash of the password
+func (h *Hasher) Hash(password []byte) ([]byte, error) {
+	if len(password) == 0 {
+		return nil, ErrEmptyPassword
+	}
+	if h.params.Algo == AlgoBcrypt {
+		return bcrypt.GenerateFromPassword(password, h.params.BcryptCost)
+	}
+
+	salt := make([]byte, h.params.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	key := argon2.IDKey(password, salt, h.params.Iterations, h.params.Memory, h.params.Parallelism, h.params.KeyLength)
+
+	encoded := fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version,
+		h.params.Memory, h.params.Iterations, h.params.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	)
+	return []byte(encoded), nil
+}
+
+// Verify checks password against encoded hash. needsRehash is true when the
+// hash was produced with another algorithm or weaker parameters than the
+// current ones and should be replaced after successful login.
+func (h *Hasher) Verify(password []byte, encoded []byte) (needsRehash bool, err error) {
+	hash := string(encoded)
+	switch {
+	case strings.HasPrefix(hash, "$argon2id$"):
+		return h.verifyArgon2id(password, hash)
+	case strings.HasPrefix(hash, "$2a$"), strings.HasPrefix(hash, "$2b$"), strings.HasPrefix(hash, "$2y$"):
+		return h.verifyBcrypt(password, encoded)
+	case strings.HasPrefix(hash, "$"):
+		return false, ErrUnknownAlgo
+	case strings.Contains(hash, "$"):
+		// Похоже на повреждённый хеш, сравнивать его с паролем как текст нельзя
+		return false, ErrInvalidHash
+	}
+
+	// Пароли, сохранённые до появления хеширования, лежат в базе как есть.
+	if len(encoded) == 0 || subtle.ConstantTimeCompare(password, encoded) != 1 {
+		return false, ErrMismatch
+	}
+	return true, nil
+}
+
+func (h *Hasher) verifyBcrypt(password []byte, encoded []byte) (bool, error) {
+	if err := bcrypt.CompareHashAndPassword(encoded, password); err != nil {
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return false, ErrMismatch
+		}
+		return false, err
+	}
+	if h.params.Algo != AlgoBcrypt {
+		return true, nil
+	}
+	cost, err := bcrypt.Cost(encoded)
+	if err != nil {
+		return false, err
+	}
+	return cost < h.params.BcryptCost, nil
+}
+
+func (h *Hasher) verifyArgon2id(password []byte, encoded string) (bool, error) {
+	p, salt, key, err := decodeArgon2id(encoded)
+	if err != nil {
+		return false, err
+	}
+
+	other := argon2.IDKey(password, salt, p.Iterations, p.Memory, p.Parallelism, p.KeyLength)
+	if subtle.ConstantTimeCompare(key, other) != 1 {
+		return false, ErrMismatch
+	}
+
+	if h.params.Algo != AlgoArgon2id {
+		return true, nil
+	}
+	needsRehash := p.Memory < h.params.Memory ||
+		p.Iterations < h.params.Iterations ||
+		p.Parallelism < h.params.Parallelism ||
+		p.KeyLength < h.params.KeyLength ||
+		uint32(len(salt)) < h.params.SaltLength
+	return needsRehash, nil
+}
+
+func decodeArgon2id(encoded string) (Params, []byte, []byte, error) {
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 {
+		return Params{}, nil, nil, ErrInvalidHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
+		return Params{}, nil, nil, ErrInvalidHash
+	}
+	if version != argon2.Version {
+		return Params{}, nil, nil, ErrIncompatible
+	}
+
+	p := Params{Algo: AlgoArgon2id}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &p.Memory, &p.Iterations, &p.Parallelism); err != nil {
+		return Params{}, nil, nil, ErrInvalidHash
+	}
+	// Нулевые параметры роняют argon2, лишние символы после них тоже считаем повреждением
+	if p.Memory == 0 || p.Iterations == 0 || p.Parallelism == 0 ||
+		parts[3] != fmt.Sprintf("m=%d,t=%d,p=%d", p.Memory, p.Iterations, p.Parallelism) {
+		return Params{}, nil, nil, ErrInvalidHash
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil || len(salt) == 0 {
+		return Params{}, nil, nil, ErrInvalidHash
+	}
+	// Пустой ключ совпал бы с любым паролем
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return Params{}, nil, nil, ErrInvalidHash
+	}
+	p.SaltLength = uint32(len(salt))
+	p.KeyLength = uint32(len(key))
+
+	return p, salt, key, nil
+}
@@ -0,0 +1,212 @@
+package authHash
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// testParams are weak argon2id parameters, fast enough for tests
+func testParams() Params {
+	return Params{
+		Algo:        AlgoArgon2id,
+		Memory:      1024,
+		Iterations:  1,
+		Parallelism: 1,
+		SaltLength:  16,
+		KeyLength:   32,
+		BcryptCost:  bcrypt.MinCost,
+	}
+}
+
+func newTestHasher(t *testing.T, modify func(p *Params)) *Hasher {
+	t.Helper()
+	params := testParams()
+	if modify != nil {
+		modify(&params)
+	}
+	h, err := NewHasher(params)
+	if err != nil {
+		t.Fatalf("NewHasher() error = %v", err)
+	}
+	return h
+}
+
+func hash(t *testing.T, h *Hasher, password string) []byte {
+	t.Helper()
+	encoded, err := h.Hash([]byte(password))
+	if err != nil {
+		t.Fatalf("Hash() error = %v", err)
+	}
+	return encoded
+}
+
+func TestArgon2idRoundTrip(t *testing.T) {
+	h := newTestHasher(t, nil)
+	encoded := hash(t, h, "correct horse")
+
+	if !strings.HasPrefix(string(encoded), "$argon2id$v=19$m=1024,t=1,p=1$") {
+		t.Fatalf("Hash() = %q, want PHC string with hasher params", encoded)
+	}
+	p, salt, key, err := decodeArgon2id(string(encoded))
+	if err != nil {
+		t.Fatalf("decodeArgon2id() error = %v", err)
+	}
+	want := testParams()
+	want.BcryptCost = 0
+	if p != want {
+		t.Errorf("decoded params = %+v, want %+v", p, want)
+	}
+	if len(salt) != 16 || len(key) != 32 {
+		t.Errorf("salt length = %d, key length = %d", len(salt), len(key))
+	}
+
+	needsRehash, err := h.Verify([]byte("correct horse"), encoded)
+	if err != nil || needsRehash {
+		t.Errorf("Verify() = %v, %v, want false, nil", needsRehash, err)
+	}
+	if _, err := h.Verify([]byte("wrong horse"), encoded); !errors.Is(err, ErrMismatch) {
+		t.Errorf("Verify() wrong password error = %v, want ErrMismatch", err)
+	}
+	// Соль случайная, одинаковые пароли дают разные хеши
+	if bytes.Equal(encoded, hash(t, h, "correct horse")) {
+		t.Error("Hash() returned the same hash twice")
+	}
+}
+
+func TestBcryptVerify(t *testing.T) {
+	h := newTestHasher(t, func(p *Params) { p.Algo = AlgoBcrypt })
+	encoded := hash(t, h, "correct horse")
+
+	needsRehash, err := h.Verify([]byte("correct horse"), encoded)
+	if err != nil || needsRehash {
+		t.Errorf("Verify() = %v, %v, want false, nil", needsRehash, err)
+	}
+	if _, err := h.Verify([]byte("wrong horse"), encoded); !errors.Is(err, ErrMismatch) {
+		t.Errorf("Verify() wrong password error = %v, want ErrMismatch", err)
+	}
+}
+
+func TestHashEmptyPassword(t *testing.T) {
+	h := newTestHasher(t, nil)
+	if _, err := h.Hash(nil); !errors.Is(err, ErrEmptyPassword) {
+		t.Errorf("Hash() error = %v, want ErrEmptyPassword", err)
+	}
+}
+
+func TestNeedsRehash(t *testing.T) {
+	argon2id := newTestHasher(t, nil)
+	bcryptHasher := newTestHasher(t, func(p *Params) { p.Algo = AlgoBcrypt })
+
+	tests := []struct {
+		name    string
+		stored  *Hasher
+		current *Hasher
+		want    bool
+	}{
+		{name: "same argon2id params", stored: argon2id, current: argon2id, want: false},
+		{name: "same bcrypt cost", stored: bcryptHasher, current: bcryptHasher, want: false},
+		{
+			name:    "more argon2id memory",
+			stored:  argon2id,
+			current: newTestHasher(t, func(p *Params) { p.Memory = 2048 }),
+			want:    true,
+		},
+		{
+			name:    "more argon2id iterations",
+			stored:  argon2id,
+			current: newTestHasher(t, func(p *Params) { p.Iterations = 2 }),
+			want:    true,
+		},
+		{
+			name:    "longer salt",
+			stored:  argon2id,
+			current: newTestHasher(t, func(p *Params) { p.SaltLength = 32 }),
+			want:    true,
+		},
+		{
+			name:    "weaker argon2id params",
+			stored:  newTestHasher(t, func(p *Params) { p.Memory = 2048 }),
+			current: argon2id,
+			want:    false,
+		},
+		{
+			name:    "higher bcrypt cost",
+			stored:  bcryptHasher,
+			current: newTestHasher(t, func(p *Params) { p.Algo = AlgoBcrypt; p.BcryptCost = bcrypt.MinCost + 1 }),
+			want:    true,
+		},
+		{name: "bcrypt to argon2id", stored: bcryptHasher, current: argon2id, want: true},
+		{name: "argon2id to bcrypt", stored: argon2id, current: bcryptHasher, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			needsRehash, err := tt.current.Verify([]byte("correct horse"), hash(t, tt.stored, "correct horse"))
+			if err != nil {
+				t.Fatalf("Verify() error = %v", err)
+			}
+			if needsRehash != tt.want {
+				t.Errorf("needsRehash = %v, want %v", needsRehash, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerifyLegacyPlaintext(t *testing.T) {
+	h := newTestHasher(t, nil)
+
+	needsRehash, err := h.Verify([]byte("secret"), []byte("secret"))
+	if err != nil || !needsRehash {
+		t.Errorf("Verify() = %v, %v, want true, nil", needsRehash, err)
+	}
+	if _, err := h.Verify([]byte("other"), []byte("secret")); !errors.Is(err, ErrMismatch) {
+		t.Errorf("Verify() wrong password error = %v, want ErrMismatch", err)
+	}
+	if _, err := h.Verify(nil, nil); !errors.Is(err, ErrMismatch) {
+		t.Errorf("Verify() empty hash error = %v, want ErrMismatch", err)
+	}
+}
+
+func TestVerifyMalformed(t *testing.T) {
+	h := newTestHasher(t, nil)
+	valid := string(hash(t, h, "correct horse"))
+	parts := strings.Split(valid, "$")
+	salt, key := parts[4], parts[5]
+
+	tests := []struct {
+		name    string
+		encoded string
+		want    error
+	}{
+		{name: "missing key", encoded: "$argon2id$v=19$m=1024,t=1,p=1$" + salt, want: ErrInvalidHash},
+		{name: "extra part", encoded: valid + "$extra", want: ErrInvalidHash},
+		{name: "other version", encoded: "$argon2id$v=16$m=1024,t=1,p=1$" + salt + "$" + key, want: ErrIncompatible},
+		{name: "non-numeric version", encoded: "$argon2id$v=x$m=1024,t=1,p=1$" + salt + "$" + key, want: ErrInvalidHash},
+		{name: "missing params", encoded: "$argon2id$v=19$m=1024$" + salt + "$" + key, want: ErrInvalidHash},
+		{name: "zero iterations", encoded: "$argon2id$v=19$m=1024,t=0,p=1$" + salt + "$" + key, want: ErrInvalidHash},
+		{name: "zero memory", encoded: "$argon2id$v=19$m=0,t=1,p=1$" + salt + "$" + key, want: ErrInvalidHash},
+		{name: "parallelism overflow", encoded: "$argon2id$v=19$m=1024,t=1,p=300$" + salt + "$" + key, want: ErrInvalidHash},
+		{name: "trailing params", encoded: "$argon2id$v=19$m=1024,t=1,p=1,x=2$" + salt + "$" + key, want: ErrInvalidHash},
+		{name: "bad salt encoding", encoded: "$argon2id$v=19$m=1024,t=1,p=1$!!!$" + key, want: ErrInvalidHash},
+		{name: "empty salt", encoded: "$argon2id$v=19$m=1024,t=1,p=1$$" + key, want: ErrInvalidHash},
+		{name: "empty key", encoded: "$argon2id$v=19$m=1024,t=1,p=1$" + salt + "$", want: ErrInvalidHash},
+		{name: "unknown algorithm", encoded: "$scrypt$ln=15,r=8,p=1$" + salt + "$" + key, want: ErrUnknownAlgo},
+		{name: "no leading dollar", encoded: strings.TrimPrefix(valid, "$"), want: ErrInvalidHash},
+		{name: "dollar inside", encoded: "secret$", want: ErrInvalidHash},
+		{name: "short bcrypt", encoded: "$2b$04$short", want: bcrypt.ErrHashTooShort},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := h.Verify([]byte("correct horse"), []byte(tt.encoded)); !errors.Is(err, tt.want) {
+				t.Errorf("Verify() error = %v, want %v", err, tt.want)
+			}
+			// Повреждённый хеш не должен приниматься как пароль открытым текстом
+			if _, err := h.Verify([]byte(tt.encoded), []byte(tt.encoded)); err == nil {
+				t.Error("Verify() accepted the hash itself as password")
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package authJWT
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	TokenTypeAccess            = "access"
+	TokenTypeRefresh           = "refresh"
+	TokenTypeEmailVerification = "email_verification"
+)
+
+// Кому выдан access токен: пользователю или сервису по client_credentials.
+// Токены без sub_type выпущены до его появления и принадлежат пользователям.
+const (
+	SubjectTypeUser    = "user"
+	SubjectTypeService = "service"
+)
+
+var ErrTokenType = errors.New("unexpected token type")
+
+// Claims are claims of access, refresh and email verification tokens.
+// Access tokens carry sub (user id), aud from settings and the user profile,
+// other tokens are addressed to the issuer itself and carry only the session or email.
+// Access tokens issued to an application on behalf of the user also carry its client_id (RFC 9068),
+// tokens of services have sub_type service, sub and client_id of the service and no session.
+// Both are addressed to the client (aud is client_id), not to the services from settings.
+type Claims struct {
+	jwt.RegisteredClaims
+	TokenType  string    `json:"typ"`
+	SessionID  string    `json:"uuid,omitempty"`
+	Username   string    `json:"username,omitempty"`
+	Email      string    `json:"email,omitempty"`
+	TelegramID uint      `json:"telegram_id,omitempty"`
+	CreatedAt  time.Time `json:"created_at,omitzero"`
+	UpdatedAt  time.Time `json:"updated_at,omitzero"`
+	Scope      string    `json:"scope,omitempty"`
+	ClientID   string    `json:"client_id,omitempty"`
+	// SubjectType отличает сервисы от пользователей, sub сервиса — его client_id, а не id пользователя
+	SubjectType string `json:"sub_type,omitempty"`
+}
+
+// Scopes returns space separated scope claim as a slice
+func (c *Claims) Scopes() []string {
+	return strings.Fields(c.Scope)
+}
+
+// IsService reports whether token was issued to a service rather than to a user
+func (c *Claims) IsService() bool {
+	return c.SubjectType == SubjectTypeService
+}
+
+// Validator checks issuer, audience and type of token claims
+type Validator struct {
+	Issuer string
+	// Audience: токен должен быть адресован хотя бы одному из них
+	Audience []string
+	// TokenType пустой — принимается токен любого типа
+	TokenType string
+}
+
+// Validate checks registered claims that jwt.Parser doesn't know how to check
+func (v Validator) Validate(c *Claims) error {
+	if v.TokenType != "" && c.TokenType != v.TokenType {
+		return ErrTokenType
+	}
+	if c.IssuedAt == nil {
+		return jwt.ErrTokenRequiredClaimMissing
+	}
+	if v.Issuer != "" && c.Issuer != v.Issuer {
+		return jwt.ErrTokenInvalidIssuer
+	}
+
+	audience := v.Audience
+	switch {
+	// Все токены, кроме access, адресованы только самому сервису
+	case c.TokenType != TokenTypeAccess:
+		audience = []string{v.Issuer}
+	// Токены приложений и сервисов адресованы им самим, принимать ли их, решает получатель
+	case c.ClientID != "":
+		audience = []string{c.ClientID}
+	}
+	if len(audience) > 0 && !slices.ContainsFunc(c.Audience, func(aud string) bool {
+		return slices.Contains(audience, aud)
+	}) {
+		return jwt.ErrTokenInvalidAudience
+	}
+	return nil
+}
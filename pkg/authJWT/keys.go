@@ -0,0 +1,66 @@
+package authJWT
+
+import (
+	"encoding/base64"
+	"errors"
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/SeiFlow-3P2/auth_service/internal/domain"
+)
+
+// signToken signs claims with the active key if keys are configured, otherwise with HS256 secret
+func signToken(claims jwt.Claims, Settings *domain.AppSettings) (string, error) {
+	if Settings.Keys != nil {
+		key, err := Settings.Keys.Active()
+		if err != nil {
+			return "", err
+		}
+		token := jwt.NewWithClaims(key.Method, claims)
+		token.Header["kid"] = key.ID
+		return token.SignedString(key.Private)
+	}
+
+	secret, err := base64.StdEncoding.DecodeString(Settings.Secret)
+	if err != nil {
+		return "", err
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(secret)
+}
+
+// verificationKey picks key for the token by its kid and alg
+func verificationKey(token *jwt.Token, Settings *domain.AppSettings) (interface{}, error) {
+	if token.Method.Alg() == jwt.SigningMethodHS256.Alg() {
+		// Токены, выпущенные до перехода на асимметричные ключи, живут до истечения
+		if Settings.Secret == "" {
+			return nil, errors.New("hs256 tokens are not accepted")
+		}
+		return base64.StdEncoding.DecodeString(Settings.Secret)
+	}
+
+	if Settings.Keys == nil {
+		return nil, errors.New("no verification key")
+	}
+	kid, _ := token.Header["kid"].(string)
+	key, ok := Settings.Keys.Get(kid)
+	if !ok || token.Method.Alg() != key.Method.Alg() {
+		return nil, errors.New("unknown signing key")
+	}
+	return key.Public(), nil
+}
+
+func validMethods(Settings *domain.AppSettings) []string {
+	methods := make([]string, 0, 4)
+	if Settings.Keys != nil {
+		seen := make(map[string]bool)
+		for _, key := range Settings.Keys.Keys() {
+			if alg := key.Method.Alg(); !seen[alg] {
+				seen[alg] = true
+				methods = append(methods, alg)
+			}
+		}
+	}
+	if Settings.Secret != "" {
+		methods = append(methods, jwt.SigningMethodHS256.Alg())
+	}
+	return methods
+}
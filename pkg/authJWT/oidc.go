@@ -0,0 +1,105 @@
+package authJWT
+
+import (
+	"errors"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+
+	"github.com/SeiFlow-3P2/auth_service/internal/domain"
+)
+
+// ErrNoSigningKey ID токен проверяют сторонние приложения, общий секрет HS256 им не выдаётся
+var ErrNoSigningKey = errors.New("id tokens require an asymmetric signing key")
+
+// UserClaims returns standard OpenID Connect claims of the user allowed by scopes.
+// Empty email is not included.
+func UserClaims(User domain.User, scopes []string) map[string]any {
+	claims := map[string]any{"sub": User.ID.String()}
+	if slices.Contains(scopes, domain.ScopeProfile) {
+		claims["name"] = User.Username
+		claims["preferred_username"] = User.Username
+		if !User.UpdatedAt.IsZero() {
+			claims["updated_at"] = User.UpdatedAt.Unix()
+		}
+		if User.PhotoUrl != "" {
+			claims["picture"] = User.PhotoUrl
+		}
+	}
+	if slices.Contains(scopes, domain.ScopeEmail) && User.Email != "" {
+		claims["email"] = User.Email
+		claims["email_verified"] = User.EmailVerified()
+	}
+	return claims
+}
+
+// CreateIDToken creates OpenID Connect ID token of the user for the client
+func CreateIDToken(User domain.User, clientID string, nonce string, authTime time.Time, scopes []string, Settings *domain.AppSettings) (string, error) {
+	if Settings.Keys == nil {
+		return "", ErrNoSigningKey
+	}
+
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"iss":       Settings.Issuer,
+		"aud":       clientID,
+		"azp":       clientID,
+		"iat":       now.Unix(),
+		"exp":       now.Add(Settings.AccessTTL).Unix(),
+		"auth_time": authTime.Unix(),
+	}
+	if nonce != "" {
+		claims["nonce"] = nonce
+	}
+	maps.Copy(claims, UserClaims(User, scopes))
+	return signToken(claims, Settings)
+}
+
+// CreateClientAccessToken creates access token issued to the client on behalf of the user.
+// It carries only granted scopes and profile claims allowed by them, admin scope is never
+// delegated to applications. The token is addressed to the client itself, so services
+// from JWT_AUDIENCE don't take it for a first-party token.
+func CreateClientAccessToken(User domain.User, sessionID uuid.UUID, clientID string, scopes []string, Settings *domain.AppSettings) (string, error) {
+	registered, err := registeredClaims(User.ID.String(), []string{clientID}, Settings.AccessTTL, Settings)
+	if err != nil {
+		return "", err
+	}
+
+	claims := &Claims{
+		RegisteredClaims: registered,
+		TokenType:        TokenTypeAccess,
+		SubjectType:      SubjectTypeUser,
+		SessionID:        sessionID.String(),
+		Scope:            strings.Join(scopes, " "),
+		ClientID:         clientID,
+	}
+	if slices.Contains(scopes, domain.ScopeProfile) {
+		claims.Username = User.Username
+	}
+	if slices.Contains(scopes, domain.ScopeEmail) {
+		claims.Email = User.Email
+	}
+	return signToken(claims, Settings)
+}
+
+// CreateServiceToken creates access token of the service for client_credentials grant.
+// The token is not bound to a session, lives ttl and is addressed to the client itself.
+func CreateServiceToken(clientID string, scopes []string, ttl time.Duration, Settings *domain.AppSettings) (string, error) {
+	registered, err := registeredClaims(clientID, []string{clientID}, ttl, Settings)
+	if err != nil {
+		return "", err
+	}
+
+	claims := &Claims{
+		RegisteredClaims: registered,
+		TokenType:        TokenTypeAccess,
+		SubjectType:      SubjectTypeService,
+		Scope:            strings.Join(scopes, " "),
+		ClientID:         clientID,
+	}
+	return signToken(claims, Settings)
+}
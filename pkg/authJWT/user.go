@@ -2,7 +2,7 @@ package authJWT
 
 import (
 	"context"
-	"encoding/base64"
+	"errors"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 	"time"
@@ -10,69 +10,103 @@ import (
 	"github.com/SeiFlow-3P2/auth_service/internal/domain"
 )
 
-func createAccessToken(ctx context.Context, User domain.User, tokenUUID uuid.UUID, Settings *domain.AppSettings) (string, error) {
-
-	token := jwt.New(jwt.SigningMethodHS256)
-	claims := token.Claims.(jwt.MapClaims)
-
-	claims["uuid"] = tokenUUID
-	claims["username"] = User.Username
-	claims["email"] = User.Email
-	claims["telegram_id"] = User.TelegramId
-	claims["created_at"] = User.CreatedAt
-	claims["updated_at"] = User.UpdatedAt
-	claims["exp"] = time.Now().Add(Settings.AccessTTL).Unix()
+// leeway допускает расхождение часов между сервисами
+const leeway = 30 * time.Second
 
-	secret, err := base64.StdEncoding.DecodeString(Settings.Secret)
+func registeredClaims(subject string, audience []string, ttl time.Duration, Settings *domain.AppSettings) (jwt.RegisteredClaims, error) {
+	jti, err := uuid.NewRandom()
 	if err != nil {
-		return "", err
+		return jwt.RegisteredClaims{}, err
 	}
 
-	tokenString, err := token.SignedString(secret)
+	now := time.Now()
+	return jwt.RegisteredClaims{
+		ID:        jti.String(),
+		Issuer:    Settings.Issuer,
+		Subject:   subject,
+		Audience:  audience,
+		IssuedAt:  jwt.NewNumericDate(now),
+		NotBefore: jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+	}, nil
+}
+
+func createAccessToken(ctx context.Context, User domain.User, tokenUUID uuid.UUID, Settings *domain.AppSettings) (string, error) {
+	registered, err := registeredClaims(User.ID.String(), Settings.Audience, Settings.AccessTTL, Settings)
 	if err != nil {
 		return "", err
 	}
 
-	return tokenString, nil
+	claims := &Claims{
+		RegisteredClaims: registered,
+		TokenType:        TokenTypeAccess,
+		SubjectType:      SubjectTypeUser,
+		SessionID:        tokenUUID.String(),
+		Username:         User.Username,
+		Email:            User.Email,
+		TelegramID:       User.TelegramId,
+		CreatedAt:        User.CreatedAt,
+		UpdatedAt:        User.UpdatedAt,
+	}
+	if User.IsAdmin {
+		claims.Scope = domain.ScopeAdmin
+	}
+	return signToken(claims, Settings)
 }
 
-func createRefreshToken(ctx context.Context, User domain.User, tokenUUID uuid.UUID, Settings *domain.AppSettings) (string, error) {
-	token := jwt.New(jwt.SigningMethodHS256)
-
-	claims := token.Claims.(jwt.MapClaims)
-
-	claims["uuid"] = tokenUUID
-	claims["email"] = User.Email
-	claims["exp"] = time.Now().Add(Settings.RefreshTTL).Unix()
-
-	secret, err := base64.StdEncoding.DecodeString(Settings.Secret)
+func createRefreshToken(ctx context.Context, User domain.User, tokenUUID uuid.UUID, Settings *domain.AppSettings) (string, string, error) {
+	// Refresh токен предназначен только самому сервису авторизации
+	registered, err := registeredClaims(User.ID.String(), []string{Settings.Issuer}, Settings.RefreshTTL, Settings)
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
 
-	tokenString, err := token.SignedString(secret)
-	if err != nil {
-		return "", err
+	claims := &Claims{
+		RegisteredClaims: registered,
+		TokenType:        TokenTypeRefresh,
+		SessionID:        tokenUUID.String(),
+		Email:            User.Email,
 	}
-
-	return tokenString, nil
+	token, err := signToken(claims, Settings)
+	return token, registered.ID, err
 }
 
-func CreateTokenPair(ctx context.Context, User domain.User, Settings *domain.AppSettings) (domain.Tokens, error) {
-	tokensUUID, err := uuid.NewRandom()
+// CreateTokenPair creates access and refresh tokens bound to the session
+func CreateTokenPair(ctx context.Context, User domain.User, sessionID uuid.UUID, Settings *domain.AppSettings) (domain.Tokens, error) {
+	accessToken, err := createAccessToken(ctx, User, sessionID, Settings)
 	if err != nil {
 		return domain.Tokens{}, err
 	}
 
-	accessToken, err := createAccessToken(ctx, User, tokensUUID, Settings)
+	// Каждый refresh токен сессии уникален, по jti отличаем текущий от уже использованных
+	refreshToken, refreshID, err := createRefreshToken(ctx, User, sessionID, Settings)
 	if err != nil {
 		return domain.Tokens{}, err
 	}
 
-	refreshToken, err := createRefreshToken(ctx, User, tokensUUID, Settings)
+	return domain.Tokens{AccessToken: accessToken, RefreshToken: refreshToken, RefreshTokenID: refreshID}, nil
+}
+
+// ParseToken verifies token signature, registered claims and type and returns its claims.
+// Empty tokenType accepts both access and refresh tokens.
+func ParseToken(tokenString string, tokenType string, Settings *domain.AppSettings) (*Claims, error) {
+	claims := &Claims{}
+	_, err := jwt.ParseWithClaims(tokenString, claims,
+		func(token *jwt.Token) (interface{}, error) {
+			return verificationKey(token, Settings)
+		},
+		jwt.WithValidMethods(validMethods(Settings)),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(leeway),
+	)
 	if err != nil {
-		return domain.Tokens{}, err
+		return nil, err
 	}
 
-	return domain.Tokens{AccessToken: accessToken, RefreshToken: refreshToken}, nil
+	validator := Validator{Issuer: Settings.Issuer, Audience: Settings.Audience, TokenType: tokenType}
+	if err := validator.Validate(claims); err != nil {
+		return nil, errors.Join(jwt.ErrTokenInvalidClaims, err)
+	}
+	return claims, nil
 }
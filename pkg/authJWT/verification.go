@@ -0,0 +1,21 @@
+package authJWT
+
+import (
+	"github.com/SeiFlow-3P2/auth_service/internal/domain"
+)
+
+// CreateEmailVerificationToken creates token that confirms the user owns his current email.
+// The token is bound to the email, so it stops working once the email is changed.
+func CreateEmailVerificationToken(User domain.User, Settings *domain.AppSettings) (string, error) {
+	registered, err := registeredClaims(User.ID.String(), []string{Settings.Issuer}, Settings.EmailVerificationTTL, Settings)
+	if err != nil {
+		return "", err
+	}
+
+	claims := &Claims{
+		RegisteredClaims: registered,
+		TokenType:        TokenTypeEmailVerification,
+		Email:            User.Email,
+	}
+	return signToken(claims, Settings)
+}
@@ -0,0 +1,216 @@
+package authKeys
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var ErrNoActiveKey = errors.New("no active signing key")
+
+// entry is a key with the moment it becomes active
+type entry struct {
+	key       *Key
+	createdAt time.Time
+}
+
+// Keyring holds the active signing key and previous keys that still verify
+// tokens. A key is active from its creation time until the next key is
+// created, then keeps verifying for overlap (the longest token TTL).
+// Keys created in the future are already published but not used for signing.
+type Keyring struct {
+	mu      sync.RWMutex
+	entries []entry // по возрастанию createdAt
+	overlap time.Duration
+	now     func() time.Time
+}
+
+// NewKeyring returns empty keyring
+func NewKeyring(overlap time.Duration) *Keyring {
+	return &Keyring{overlap: overlap, now: time.Now}
+}
+
+// SingleKeyring returns keyring with one key that is always active
+func SingleKeyring(key *Key) *Keyring {
+	k := NewKeyring(0)
+	k.Add(key, time.Time{})
+	return k
+}
+
+// Add adds key that becomes active at createdAt
+func (k *Keyring) Add(key *Key, createdAt time.Time) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	k.entries = append(k.entries, entry{key: key, createdAt: createdAt})
+	sort.SliceStable(k.entries, func(i, j int) bool {
+		return k.entries[i].createdAt.Before(k.entries[j].createdAt)
+	})
+}
+
+// Active returns key used for signing
+func (k *Keyring) Active() (*Key, error) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	now := k.now()
+	for i := len(k.entries) - 1; i >= 0; i-- {
+		if !k.entries[i].createdAt.After(now) {
+			return k.entries[i].key, nil
+		}
+	}
+	return nil, ErrNoActiveKey
+}
+
+// Get returns verification key by kid
+func (k *Keyring) Get(kid string) (*Key, bool) {
+	for _, key := range k.Keys() {
+		if key.ID == kid {
+			return key, true
+		}
+	}
+	return nil, false
+}
+
+// Keys returns all keys that currently verify tokens
+func (k *Keyring) Keys() []*Key {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	now := k.now()
+	keys := make([]*Key, 0, len(k.entries))
+	for i, e := range k.entries {
+		if i+1 < len(k.entries) {
+			// Ключ вытеснен следующим и все выпущенные им токены уже истекли
+			next := k.entries[i+1].createdAt
+			if !next.After(now) && now.After(next.Add(k.overlap)) {
+				continue
+			}
+		}
+		keys = append(keys, e.key)
+	}
+	return keys
+}
+
+// expired returns file names of keys that no longer verify anything
+func (k *Keyring) expired() map[string]bool {
+	valid := make(map[string]bool)
+	for _, key := range k.Keys() {
+		valid[key.ID] = true
+	}
+
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	expired := make(map[string]bool)
+	for _, e := range k.entries {
+		if !valid[e.key.ID] {
+			expired[KeyFileName(e.key.ID, e.createdAt)] = true
+		}
+	}
+	return expired
+}
+
+// KeyFileName returns file name of the key in keys directory: <unix created at>-<kid>.pem
+func KeyFileName(kid string, createdAt time.Time) string {
+	return fmt.Sprintf("%d-%s.pem", createdAt.Unix(), kid)
+}
+
+func parseKeyFileName(name string) (kid string, createdAt time.Time, ok bool) {
+	base, found := strings.CutSuffix(name, ".pem")
+	if !found {
+		return "", time.Time{}, false
+	}
+	ts, kid, found := strings.Cut(base, "-")
+	if !found || kid == "" {
+		return "", time.Time{}, false
+	}
+	sec, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return "", time.Time{}, false
+	}
+	return kid, time.Unix(sec, 0), true
+}
+
+// LoadDir reads keys named by KeyFileName from directory. Other files are ignored.
+func LoadDir(dir string, overlap time.Duration) (*Keyring, error) {
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	keyring := NewKeyring(overlap)
+	for _, file := range files {
+		if file.IsDir() {
+			continue
+		}
+		kid, createdAt, ok := parseKeyFileName(file.Name())
+		if !ok {
+			continue
+		}
+		key, err := LoadKeyFile(filepath.Join(dir, file.Name()), kid)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file.Name(), err)
+		}
+		keyring.Add(key, createdAt)
+	}
+
+	if _, err := keyring.Active(); err != nil {
+		return nil, err
+	}
+	return keyring, nil
+}
+
+// Reload replaces keys with the ones from directory
+func (k *Keyring) Reload(dir string) error {
+	loaded, err := LoadDir(dir, k.overlap)
+	if err != nil {
+		return err
+	}
+
+	k.mu.Lock()
+	k.entries = loaded.entries
+	k.mu.Unlock()
+	return nil
+}
+
+// WatchDir reloads keys from directory every interval until stop is closed
+func (k *Keyring) WatchDir(dir string, interval time.Duration, stop <-chan struct{}, onError func(error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			if err := k.Reload(dir); err != nil && onError != nil {
+				onError(err)
+			}
+		}
+	}
+}
+
+// PruneDir deletes key files that no longer verify any token
+func PruneDir(dir string, overlap time.Duration) ([]string, error) {
+	keyring, err := LoadDir(dir, overlap)
+	if err != nil {
+		return nil, err
+	}
+
+	var removed []string
+	for name := range keyring.expired() {
+		if err := os.Remove(filepath.Join(dir, name)); err != nil {
+			return removed, err
+		}
+		removed = append(removed, name)
+	}
+	sort.Strings(removed)
+	return removed, nil
+}
@@ -0,0 +1,120 @@
+package authKeys
+
+import (
+	"context"
+	"crypto"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RemoteJWKS caches public keys from a JWKS endpoint of this or another issuer
+type RemoteJWKS struct {
+	URL    string
+	Client *http.Client
+	// CacheTTL сколько держать ключи, неизвестный kid обновляет их раньше, но не чаще MinRefresh
+	CacheTTL   time.Duration
+	MinRefresh time.Duration
+
+	mu          sync.Mutex
+	keys        map[string]crypto.PublicKey
+	jwks        map[string]JWK
+	fetchedAt   time.Time
+	attemptedAt time.Time
+}
+
+// NewRemoteJWKS returns key cache for jwks url
+func NewRemoteJWKS(url string) *RemoteJWKS {
+	return &RemoteJWKS{
+		URL:        url,
+		Client:     &http.Client{Timeout: 10 * time.Second},
+		CacheTTL:   10 * time.Minute,
+		MinRefresh: 30 * time.Second,
+	}
+}
+
+func (r *RemoteJWKS) fetch(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.URL, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := r.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("jwks: unexpected status %d", resp.StatusCode)
+	}
+
+	var jwks JWKS
+	if err := json.NewDecoder(resp.Body).Decode(&jwks); err != nil {
+		return err
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(jwks.Keys))
+	byKid := make(map[string]JWK, len(jwks.Keys))
+	for _, jwk := range jwks.Keys {
+		// Ключи шифрования в JWKS провайдеров для подписи не подходят
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		pub, err := jwk.PublicKey()
+		if err != nil {
+			continue
+		}
+		keys[jwk.Kid] = pub
+		byKid[jwk.Kid] = jwk
+	}
+	r.keys, r.jwks, r.fetchedAt = keys, byKid, time.Now()
+	return nil
+}
+
+// algMatches reports whether key can verify signature of alg. Some providers
+// publish keys without alg, then it is derived from the key type.
+func algMatches(jwk JWK, alg string) bool {
+	if jwk.Alg != "" {
+		return jwk.Alg == alg
+	}
+	switch jwk.Kty {
+	case "RSA":
+		return strings.HasPrefix(alg, "RS") || strings.HasPrefix(alg, "PS")
+	case "EC":
+		return alg == "ES256"
+	case "OKP":
+		return alg == "EdDSA"
+	}
+	return false
+}
+
+// Key returns public key by kid for signature algorithm alg.
+// Empty kid is accepted only if the set has a single key.
+func (r *RemoteJWKS) Key(ctx context.Context, kid string, alg string) (crypto.PublicKey, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	_, known := r.keys[kid]
+	stale := now.Sub(r.fetchedAt) > r.CacheTTL
+	if (stale || !known) && now.Sub(r.attemptedAt) > r.MinRefresh {
+		r.attemptedAt = now
+		// При недоступности издателя продолжаем работать со старыми ключами
+		if err := r.fetch(ctx); err != nil && r.keys == nil {
+			return nil, err
+		}
+	}
+
+	if kid == "" && len(r.jwks) == 1 {
+		for id := range r.jwks {
+			kid = id
+		}
+	}
+	key, ok := r.keys[kid]
+	if !ok || !algMatches(r.jwks[kid], alg) {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+	return key, nil
+}
@@ -0,0 +1,24 @@
+package authMail
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/SeiFlow-3P2/auth_service/internal/domain"
+)
+
+// LogSender doesn't send anything and only writes emails to the log.
+// Используется, пока не настроена настоящая отправка почты.
+type LogSender struct {
+	Logger *slog.Logger
+}
+
+// Send writes email to the log
+func (s LogSender) Send(ctx context.Context, mail domain.Mail) error {
+	s.Logger.InfoContext(ctx, "mail",
+		slog.String("to", mail.To),
+		slog.String("subject", mail.Subject),
+		slog.String("text", mail.Text),
+	)
+	return nil
+}
@@ -0,0 +1,157 @@
+package authMail
+
+import (
+	"bytes"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"strings"
+	"testing"
+
+	"github.com/SeiFlow-3P2/auth_service/internal/domain"
+)
+
+const testFrom = "Auth Service <noreply@example.com>"
+
+func parseMessage(t *testing.T, msg []byte) *mail.Message {
+	t.Helper()
+	// Строки письма заканчиваются CRLF и не длиннее 998 символов (RFC 5322)
+	for _, line := range strings.Split(strings.TrimSuffix(string(msg), "\r\n"), "\r\n") {
+		if strings.Contains(line, "\n") {
+			t.Fatalf("line %q has bare LF", line)
+		}
+		if len(line) > 998 {
+			t.Fatalf("line %q is longer than 998 characters", line)
+		}
+	}
+	parsed, err := mail.ReadMessage(bytes.NewReader(msg))
+	if err != nil {
+		t.Fatalf("ReadMessage() error = %v", err)
+	}
+	return parsed
+}
+
+func readQuoted(t *testing.T, r io.Reader) string {
+	t.Helper()
+	body, err := io.ReadAll(quotedprintable.NewReader(r))
+	if err != nil {
+		t.Fatalf("quoted-printable body error = %v", err)
+	}
+	return string(body)
+}
+
+func TestBuildMessageHeaders(t *testing.T) {
+	msg, err := buildMessage(testFrom, domain.Mail{To: "ivan@example.com", Subject: "Подтверждение почты", Text: "text"})
+	if err != nil {
+		t.Fatalf("buildMessage() error = %v", err)
+	}
+	parsed := parseMessage(t, msg)
+
+	for key, want := range map[string]string{
+		"From":         testFrom,
+		"To":           "ivan@example.com",
+		"MIME-Version": "1.0",
+	} {
+		if got := parsed.Header.Get(key); got != want {
+			t.Errorf("%s = %q, want %q", key, got, want)
+		}
+	}
+	// Тема не-ASCII кодируется по RFC 2047
+	rawSubject := parsed.Header.Get("Subject")
+	if !strings.HasPrefix(rawSubject, "=?utf-8?q?") {
+		t.Errorf("Subject = %q, want Q-encoded word", rawSubject)
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(rawSubject)
+	if err != nil || subject != "Подтверждение почты" {
+		t.Errorf("decoded Subject = %q, %v", subject, err)
+	}
+	if _, err := parsed.Header.Date(); err != nil {
+		t.Errorf("Date error = %v", err)
+	}
+	if id := parsed.Header.Get("Message-ID"); !strings.HasPrefix(id, "<") || !strings.HasSuffix(id, "@auth-service>") {
+		t.Errorf("Message-ID = %q", id)
+	}
+
+	other, err := buildMessage(testFrom, domain.Mail{To: "ivan@example.com", Subject: "s", Text: "text"})
+	if err != nil {
+		t.Fatalf("buildMessage() error = %v", err)
+	}
+	if parseMessage(t, other).Header.Get("Message-ID") == parsed.Header.Get("Message-ID") {
+		t.Error("buildMessage() reused Message-ID")
+	}
+}
+
+func TestBuildMessagePlainText(t *testing.T) {
+	// Длинная строка с кириллицей и знаком "=" проверяет перенос и экранирование
+	text := "Здравствуйте!\r\nСсылка: https://example.com/verify?token=" + strings.Repeat("a", 100) + "\r\n"
+	msg, err := buildMessage(testFrom, domain.Mail{To: "ivan@example.com", Subject: "s", Text: text})
+	if err != nil {
+		t.Fatalf("buildMessage() error = %v", err)
+	}
+	parsed := parseMessage(t, msg)
+
+	if got := parsed.Header.Get("Content-Type"); got != "text/plain; charset=utf-8" {
+		t.Errorf("Content-Type = %q", got)
+	}
+	if got := parsed.Header.Get("Content-Transfer-Encoding"); got != "quoted-printable" {
+		t.Errorf("Content-Transfer-Encoding = %q", got)
+	}
+	body, err := io.ReadAll(parsed.Body)
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+	// quoted-printable переносит строки длиннее 76 символов
+	for _, line := range strings.Split(string(body), "\r\n") {
+		if len(line) > 76 {
+			t.Errorf("body line %q is longer than 76 characters", line)
+		}
+	}
+	if got := readQuoted(t, bytes.NewReader(body)); got != text {
+		t.Errorf("body = %q, want %q", got, text)
+	}
+}
+
+func TestBuildMessageMultipart(t *testing.T) {
+	mail := domain.Mail{
+		To:      "ivan@example.com",
+		Subject: "s",
+		Text:    "Перейдите по ссылке https://example.com/?a=1",
+		HTML:    `<p>Перейдите по <a href="https://example.com/?a=1">ссылке</a></p>`,
+	}
+	msg, err := buildMessage(testFrom, mail)
+	if err != nil {
+		t.Fatalf("buildMessage() error = %v", err)
+	}
+	parsed := parseMessage(t, msg)
+
+	mediaType, params, err := mime.ParseMediaType(parsed.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/alternative" {
+		t.Fatalf("Content-Type = %q, %v", parsed.Header.Get("Content-Type"), err)
+	}
+	reader := multipart.NewReader(parsed.Body, params["boundary"])
+
+	// Сначала текстовая часть, последней html: клиенты показывают последнюю понятную
+	for _, want := range []struct{ contentType, body string }{
+		{"text/plain; charset=utf-8", mail.Text},
+		{"text/html; charset=utf-8", mail.HTML},
+	} {
+		part, err := reader.NextRawPart()
+		if err != nil {
+			t.Fatalf("NextRawPart() error = %v", err)
+		}
+		if got := part.Header.Get("Content-Type"); got != want.contentType {
+			t.Errorf("part Content-Type = %q, want %q", got, want.contentType)
+		}
+		if got := part.Header.Get("Content-Transfer-Encoding"); got != "quoted-printable" {
+			t.Errorf("part Content-Transfer-Encoding = %q", got)
+		}
+		if got := readQuoted(t, part); got != want.body {
+			t.Errorf("part body = %q, want %q", got, want.body)
+		}
+	}
+	if _, err := reader.NextRawPart(); err != io.EOF {
+		t.Errorf("NextRawPart() error = %v, want io.EOF", err)
+	}
+}
@@ -0,0 +1,44 @@
+package authMail
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/SeiFlow-3P2/auth_service/internal/domain"
+)
+
+// OutboxSender writes emails as .eml files to directory instead of sending them.
+// Используется при локальной разработке и в тестах.
+type OutboxSender struct {
+	Dir  string
+	From string
+}
+
+// Send writes email to <dir>/<unix nano>-<random>.eml
+func (s *OutboxSender) Send(ctx context.Context, mail domain.Mail) error {
+	msg, err := buildMessage(s.From, mail)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(s.Dir, 0o755); err != nil {
+		return err
+	}
+
+	suffix := make([]byte, 4)
+	if _, err := rand.Read(suffix); err != nil {
+		return err
+	}
+	name := fmt.Sprintf("%d-%s.eml", time.Now().UnixNano(), hex.EncodeToString(suffix))
+
+	// Пишем во временный файл, чтобы читатели outbox не видели недописанных писем
+	tmp := filepath.Join(s.Dir, "."+name+".tmp")
+	if err := os.WriteFile(tmp, msg, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, filepath.Join(s.Dir, name))
+}
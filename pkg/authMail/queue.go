@@ -0,0 +1,136 @@
+package authMail
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/SeiFlow-3P2/auth_service/internal/domain"
+)
+
+var (
+	ErrQueueFull   = errors.New("mail queue is full")
+	ErrQueueClosed = errors.New("mail queue is closed")
+)
+
+// QueueConfig configures async sending
+type QueueConfig struct {
+	Size        int
+	Workers     int
+	MaxAttempts int
+	// Backoff задержка перед второй попыткой, дальше удваивается
+	Backoff time.Duration
+}
+
+// Queue sends emails in background workers with retries, so requests don't wait for the mail server
+type Queue struct {
+	sender domain.MailSender
+	cfg    QueueConfig
+	log    *slog.Logger
+
+	mu     sync.RWMutex
+	closed bool
+	jobs   chan domain.Mail
+	stop   chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewQueue starts workers that send emails through sender
+func NewQueue(sender domain.MailSender, cfg QueueConfig, log *slog.Logger) *Queue {
+	if cfg.Size <= 0 {
+		cfg.Size = 100
+	}
+	if cfg.Workers <= 0 {
+		cfg.Workers = 1
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = 5
+	}
+	if cfg.Backoff <= 0 {
+		cfg.Backoff = time.Second
+	}
+
+	q := &Queue{
+		sender: sender,
+		cfg:    cfg,
+		log:    log,
+		jobs:   make(chan domain.Mail, cfg.Size),
+		stop:   make(chan struct{}),
+	}
+	for i := 0; i < cfg.Workers; i++ {
+		q.wg.Add(1)
+		go q.worker()
+	}
+	return q
+}
+
+// Send puts email to the queue and returns without waiting for delivery
+func (q *Queue) Send(ctx context.Context, mail domain.Mail) error {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	if q.closed {
+		return ErrQueueClosed
+	}
+	select {
+	case q.jobs <- mail:
+		return nil
+	default:
+		return ErrQueueFull
+	}
+}
+
+// Close stops accepting emails and waits until queued ones are sent.
+// Повторные попытки прерываются, письма, которые не удалось отправить, пишутся в лог.
+func (q *Queue) Close() error {
+	q.mu.Lock()
+	if q.closed {
+		q.mu.Unlock()
+		return nil
+	}
+	q.closed = true
+	close(q.jobs)
+	close(q.stop)
+	q.mu.Unlock()
+
+	q.wg.Wait()
+	return nil
+}
+
+func (q *Queue) worker() {
+	defer q.wg.Done()
+	for mail := range q.jobs {
+		q.send(mail)
+	}
+}
+
+func (q *Queue) send(mail domain.Mail) {
+	backoff := q.cfg.Backoff
+	for attempt := 1; ; attempt++ {
+		err := q.sender.Send(context.Background(), mail)
+		if err == nil {
+			return
+		}
+		if attempt >= q.cfg.MaxAttempts {
+			q.log.Error("mail not sent", slog.String("to", mail.To), slog.String("subject", mail.Subject),
+				slog.Int("attempts", attempt), slog.Any("err", err))
+			return
+		}
+		q.log.Warn("mail send failed, retrying", slog.String("to", mail.To),
+			slog.Int("attempt", attempt), slog.Any("err", err))
+
+		select {
+		case <-time.After(backoff):
+		case <-q.stop:
+			// Очередь закрывается, пробуем последний раз без ожидания
+			if err := q.sender.Send(context.Background(), mail); err != nil {
+				q.log.Error("mail not sent", slog.String("to", mail.To), slog.String("subject", mail.Subject),
+					slog.Int("attempts", attempt+1), slog.Any("err", err))
+			}
+			return
+		}
+		backoff *= 2
+	}
+}
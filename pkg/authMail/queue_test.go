@@ -0,0 +1,209 @@
+package authMail
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SeiFlow-3P2/auth_service/internal/domain"
+)
+
+var errSend = errors.New("smtp unavailable")
+
+// failingSender fails first failures attempts of each email and then passes it to next
+type failingSender struct {
+	next     domain.MailSender
+	failures int
+
+	mu       sync.Mutex
+	attempts map[string]int
+}
+
+func (s *failingSender) Send(ctx context.Context, mail domain.Mail) error {
+	s.mu.Lock()
+	if s.attempts == nil {
+		s.attempts = make(map[string]int)
+	}
+	s.attempts[mail.To]++
+	attempt := s.attempts[mail.To]
+	s.mu.Unlock()
+
+	if attempt <= s.failures {
+		return errSend
+	}
+	return s.next.Send(ctx, mail)
+}
+
+func (s *failingSender) attemptsOf(to string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.attempts[to]
+}
+
+// outbox returns recipients of emails written by OutboxSender
+func outbox(t *testing.T, dir string) []string {
+	t.Helper()
+	files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+	if err != nil {
+		t.Fatalf("Glob() error = %v", err)
+	}
+	var recipients []string
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatalf("ReadFile() error = %v", err)
+		}
+		msg, err := mail.ReadMessage(bytes.NewReader(data))
+		if err != nil {
+			t.Fatalf("ReadMessage(%s) error = %v", file, err)
+		}
+		recipients = append(recipients, msg.Header.Get("To"))
+	}
+	slices.Sort(recipients)
+	return recipients
+}
+
+func testMail(i int) domain.Mail {
+	return domain.Mail{To: fmt.Sprintf("user%d@example.com", i), Subject: "s", Text: "text"}
+}
+
+func newTestQueue(t *testing.T, sender domain.MailSender, cfg QueueConfig) *Queue {
+	t.Helper()
+	q := NewQueue(sender, cfg, slog.New(slog.DiscardHandler))
+	t.Cleanup(func() { _ = q.Close() })
+	return q
+}
+
+func TestQueueRetries(t *testing.T) {
+	dir := t.TempDir()
+	sender := &failingSender{next: &OutboxSender{Dir: dir, From: testFrom}, failures: 2}
+	q := newTestQueue(t, sender, QueueConfig{MaxAttempts: 3, Backoff: time.Millisecond})
+
+	if err := q.Send(context.Background(), testMail(1)); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for len(outbox(t, dir)) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("email not delivered after %d attempts", sender.attemptsOf("user1@example.com"))
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if got := sender.attemptsOf("user1@example.com"); got != 3 {
+		t.Errorf("attempts = %d, want 3", got)
+	}
+}
+
+func TestQueueGivesUp(t *testing.T) {
+	dir := t.TempDir()
+	sender := &failingSender{next: &OutboxSender{Dir: dir, From: testFrom}, failures: 100}
+	q := NewQueue(sender, QueueConfig{MaxAttempts: 3, Backoff: time.Millisecond}, slog.New(slog.DiscardHandler))
+
+	if err := q.Send(context.Background(), testMail(1)); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	// Ждём, пока воркер исчерпает попытки, Close прервал бы ожидание между ними
+	deadline := time.Now().Add(5 * time.Second)
+	for sender.attemptsOf("user1@example.com") < 3 {
+		if time.Now().After(deadline) {
+			t.Fatalf("attempts = %d, want 3", sender.attemptsOf("user1@example.com"))
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if err := q.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if got := sender.attemptsOf("user1@example.com"); got != 3 {
+		t.Errorf("attempts = %d, want 3", got)
+	}
+	if got := outbox(t, dir); len(got) != 0 {
+		t.Errorf("outbox = %v, want empty", got)
+	}
+}
+
+func TestQueueCloseDrains(t *testing.T) {
+	dir := t.TempDir()
+	// Первая попытка каждого письма неудачна, а до второй пришлось бы ждать час
+	sender := &failingSender{next: &OutboxSender{Dir: dir, From: testFrom}, failures: 1}
+	q := NewQueue(sender, QueueConfig{Workers: 2, MaxAttempts: 5, Backoff: time.Hour}, slog.New(slog.DiscardHandler))
+
+	var want []string
+	for i := range 5 {
+		mail := testMail(i)
+		if err := q.Send(context.Background(), mail); err != nil {
+			t.Fatalf("Send() error = %v", err)
+		}
+		want = append(want, mail.To)
+	}
+
+	closed := make(chan error)
+	go func() { closed <- q.Close() }()
+	select {
+	case err := <-closed:
+		if err != nil {
+			t.Fatalf("Close() error = %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Close() waits for retry backoff")
+	}
+
+	if got := outbox(t, dir); !slices.Equal(got, want) {
+		t.Errorf("outbox = %v, want %v", got, want)
+	}
+	if err := q.Send(context.Background(), testMail(10)); !errors.Is(err, ErrQueueClosed) {
+		t.Errorf("Send() after Close error = %v, want ErrQueueClosed", err)
+	}
+	if err := q.Close(); err != nil {
+		t.Errorf("second Close() error = %v", err)
+	}
+	// Временные файлы outbox не остаются в каталоге
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir() error = %v", err)
+	}
+	for _, entry := range entries {
+		if strings.HasSuffix(entry.Name(), ".tmp") {
+			t.Errorf("temporary file %s left in outbox", entry.Name())
+		}
+	}
+}
+
+// blockingSender blocks in Send until released
+type blockingSender struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (s *blockingSender) Send(ctx context.Context, mail domain.Mail) error {
+	s.started <- struct{}{}
+	<-s.release
+	return nil
+}
+
+func TestQueueFull(t *testing.T) {
+	sender := &blockingSender{started: make(chan struct{}, 10), release: make(chan struct{})}
+	q := newTestQueue(t, sender, QueueConfig{Size: 1, Workers: 1})
+	defer close(sender.release)
+
+	if err := q.Send(context.Background(), testMail(1)); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	// Воркер занят первым письмом, второе заполняет буфер
+	<-sender.started
+	if err := q.Send(context.Background(), testMail(2)); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if err := q.Send(context.Background(), testMail(3)); !errors.Is(err, ErrQueueFull) {
+		t.Errorf("Send() error = %v, want ErrQueueFull", err)
+	}
+}
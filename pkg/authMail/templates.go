@@ -0,0 +1,161 @@
+package authMail
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"path"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/SeiFlow-3P2/auth_service/internal/domain"
+)
+
+//go:embed templates
+var embedded embed.FS
+
+var ErrUnknownTemplate = errors.New("unknown mail template")
+
+// Template names used by the service
+const (
+	TemplateVerification  = "verification"
+	TemplatePasswordReset = "password_reset"
+	TemplateEmailChanged  = "email_changed"
+)
+
+// units are localized duration units: hours, minutes
+var units = map[string][2]string{
+	"ru": {"ч", "мин"},
+	"en": {"h", "min"},
+}
+
+type template struct {
+	text *texttemplate.Template
+	html *htmltemplate.Template
+}
+
+// Templates renders localized emails. Each locale is a directory with
+// <name>.txt defining "subject" and "text" blocks and optional <name>.html body.
+type Templates struct {
+	templates     map[string]map[string]template // locale -> name
+	defaultLocale string
+}
+
+// NewTemplates loads built-in templates
+func NewTemplates(defaultLocale string) (*Templates, error) {
+	sub, err := fs.Sub(embedded, "templates")
+	if err != nil {
+		return nil, err
+	}
+	return LoadTemplates(sub, defaultLocale)
+}
+
+// LoadTemplates loads templates from fsys, one directory per locale
+func LoadTemplates(fsys fs.FS, defaultLocale string) (*Templates, error) {
+	locales, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	t := &Templates{templates: make(map[string]map[string]template), defaultLocale: defaultLocale}
+	for _, locale := range locales {
+		if !locale.IsDir() {
+			continue
+		}
+		funcs := map[string]any{"duration": durationFunc(locale.Name())}
+
+		files, err := fs.Glob(fsys, path.Join(locale.Name(), "*.txt"))
+		if err != nil {
+			return nil, err
+		}
+		byName := make(map[string]template)
+		for _, file := range files {
+			name := strings.TrimSuffix(path.Base(file), ".txt")
+
+			text, err := texttemplate.New(name).Funcs(funcs).ParseFS(fsys, file)
+			if err != nil {
+				return nil, err
+			}
+			tmpl := template{text: text}
+
+			htmlFile := path.Join(locale.Name(), name+".html")
+			if _, err := fs.Stat(fsys, htmlFile); err == nil {
+				tmpl.html, err = htmltemplate.New(name+".html").Funcs(funcs).ParseFS(fsys, htmlFile)
+				if err != nil {
+					return nil, err
+				}
+			}
+			byName[name] = tmpl
+		}
+		t.templates[locale.Name()] = byName
+	}
+
+	if _, ok := t.templates[defaultLocale]; !ok {
+		return nil, fmt.Errorf("no templates for default locale %q", defaultLocale)
+	}
+	return t, nil
+}
+
+// Render renders email template for locale. Unknown locale falls back to
+// its language ("en-US" -> "en") and then to the default locale.
+func (t *Templates) Render(name string, locale string, data any) (domain.Mail, error) {
+	tmpl, ok := t.lookup(name, locale)
+	if !ok {
+		return domain.Mail{}, ErrUnknownTemplate
+	}
+
+	var subject, text bytes.Buffer
+	if err := tmpl.text.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return domain.Mail{}, err
+	}
+	if err := tmpl.text.ExecuteTemplate(&text, "text", data); err != nil {
+		return domain.Mail{}, err
+	}
+	mail := domain.Mail{
+		Subject: strings.TrimSpace(subject.String()),
+		Text:    strings.TrimLeft(text.String(), "\n"),
+	}
+
+	if tmpl.html != nil {
+		var html bytes.Buffer
+		if err := tmpl.html.Execute(&html, data); err != nil {
+			return domain.Mail{}, err
+		}
+		mail.HTML = html.String()
+	}
+	return mail, nil
+}
+
+func (t *Templates) lookup(name string, locale string) (template, bool) {
+	locale = strings.ToLower(locale)
+	language, _, _ := strings.Cut(locale, "-")
+	for _, l := range []string{locale, language, t.defaultLocale} {
+		if tmpl, ok := t.templates[l][name]; ok {
+			return tmpl, true
+		}
+	}
+	return template{}, false
+}
+
+// durationFunc returns template function that formats duration as "1 h 30 min"
+func durationFunc(locale string) func(time.Duration) string {
+	u, ok := units[locale]
+	if !ok {
+		u = units["en"]
+	}
+	return func(d time.Duration) string {
+		hours := int(d.Hours())
+		minutes := int(d.Minutes()) % 60
+		switch {
+		case hours == 0:
+			return fmt.Sprintf("%d %s", minutes, u[1])
+		case minutes == 0:
+			return fmt.Sprintf("%d %s", hours, u[0])
+		}
+		return fmt.Sprintf("%d %s %d %s", hours, u[0], minutes, u[1])
+	}
+}
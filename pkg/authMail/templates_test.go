@@ -0,0 +1,121 @@
+package authMail
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func testTemplates(t *testing.T) *Templates {
+	t.Helper()
+	fsys := fstest.MapFS{
+		"en/greeting.txt":    {Data: []byte(`{{define "subject"}}Hello{{end}}{{define "text"}}Hello, {{.}}!{{end}}`)},
+		"en/greeting.html":   {Data: []byte(`<p>Hello, {{.}}!</p>`)},
+		"ru/greeting.txt":    {Data: []byte(`{{define "subject"}}Привет{{end}}{{define "text"}}Привет, {{.}}!{{end}}`)},
+		"ru/ru_only.txt":     {Data: []byte(`{{define "subject"}}Только ru{{end}}{{define "text"}}{{.}}{{end}}`)},
+		"en-gb/greeting.txt": {Data: []byte(`{{define "subject"}}Hello there{{end}}{{define "text"}}Hello there, {{.}}!{{end}}`)},
+	}
+	templates, err := LoadTemplates(fsys, "ru")
+	if err != nil {
+		t.Fatalf("LoadTemplates() error = %v", err)
+	}
+	return templates
+}
+
+func TestRenderLocaleFallback(t *testing.T) {
+	templates := testTemplates(t)
+
+	tests := []struct {
+		name        string
+		template    string
+		locale      string
+		wantSubject string
+	}{
+		{name: "exact locale", template: "greeting", locale: "en", wantSubject: "Hello"},
+		{name: "regional locale", template: "greeting", locale: "en-GB", wantSubject: "Hello there"},
+		{name: "language of unknown region", template: "greeting", locale: "en-US", wantSubject: "Hello"},
+		{name: "unknown locale", template: "greeting", locale: "de", wantSubject: "Привет"},
+		{name: "empty locale", template: "greeting", locale: "", wantSubject: "Привет"},
+		{name: "template missing in locale", template: "ru_only", locale: "en", wantSubject: "Только ru"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mail, err := templates.Render(tt.template, tt.locale, "Ivan")
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if mail.Subject != tt.wantSubject {
+				t.Errorf("Subject = %q, want %q", mail.Subject, tt.wantSubject)
+			}
+		})
+	}
+}
+
+func TestRenderHTML(t *testing.T) {
+	templates := testTemplates(t)
+
+	mail, err := templates.Render("greeting", "en", "<b>Ivan</b>")
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if mail.Text != "Hello, <b>Ivan</b>!" {
+		t.Errorf("Text = %q", mail.Text)
+	}
+	// В html данные экранируются
+	if mail.HTML != "<p>Hello, &lt;b&gt;Ivan&lt;/b&gt;!</p>" {
+		t.Errorf("HTML = %q", mail.HTML)
+	}
+
+	mail, err = templates.Render("greeting", "ru", "Ivan")
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if mail.HTML != "" {
+		t.Errorf("HTML = %q, want empty without html template", mail.HTML)
+	}
+}
+
+func TestRenderUnknownTemplate(t *testing.T) {
+	templates := testTemplates(t)
+	if _, err := templates.Render("missing", "en", nil); !errors.Is(err, ErrUnknownTemplate) {
+		t.Errorf("Render() error = %v, want ErrUnknownTemplate", err)
+	}
+}
+
+func TestLoadTemplatesWithoutDefaultLocale(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en/greeting.txt": {Data: []byte(`{{define "subject"}}Hello{{end}}{{define "text"}}Hello{{end}}`)},
+	}
+	if _, err := LoadTemplates(fsys, "ru"); err == nil {
+		t.Fatal("LoadTemplates() accepted missing default locale")
+	}
+}
+
+func TestBuiltinTemplates(t *testing.T) {
+	templates, err := NewTemplates("ru")
+	if err != nil {
+		t.Fatalf("NewTemplates() error = %v", err)
+	}
+	data := struct {
+		Username string
+		Link     string
+		TTL      time.Duration
+	}{Username: "ivan", Link: "https://example.com/verify?token=t", TTL: 90 * time.Minute}
+
+	for _, name := range []string{TemplateVerification, TemplatePasswordReset} {
+		for locale, wantTTL := range map[string]string{"ru": "1 ч 30 мин", "en": "1 h 30 min"} {
+			mail, err := templates.Render(name, locale, data)
+			if err != nil {
+				t.Fatalf("Render(%s, %s) error = %v", name, locale, err)
+			}
+			if mail.Subject == "" || mail.HTML == "" {
+				t.Errorf("Render(%s, %s) subject = %q, html empty = %v", name, locale, mail.Subject, mail.HTML == "")
+			}
+			if !strings.Contains(mail.Text, data.Link) || !strings.Contains(mail.Text, wantTTL) {
+				t.Errorf("Render(%s, %s) text = %q", name, locale, mail.Text)
+			}
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package authMiddleware
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func tokenFromMetadata(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return ""
+	}
+	return bearer(values[0])
+}
+
+func grpcError(err error) error {
+	switch {
+	case errors.Is(err, ErrForbidden):
+		return status.Error(codes.PermissionDenied, ErrForbidden.Error())
+	case errors.Is(err, ErrNoToken):
+		return status.Error(codes.Unauthenticated, ErrNoToken.Error())
+	case errors.Is(err, ErrInvalidToken):
+		return status.Error(codes.Unauthenticated, ErrInvalidToken.Error())
+	default:
+		return status.Error(codes.Unavailable, "cant verify access token")
+	}
+}
+
+// UnaryServerInterceptor verifies bearer token from authorization metadata
+// and puts Principal into handler context
+func UnaryServerInterceptor(v Verifier, opts ...Option) grpc.UnaryServerInterceptor {
+	o := newOptions(opts)
+
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := o.authorize(ctx, v, info.FullMethod, tokenFromMetadata(ctx))
+		if err != nil {
+			return nil, grpcError(err)
+		}
+		return handler(ctx, req)
+	}
+}
+
+// wrappedStream replaces stream context
+type wrappedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (w *wrappedStream) Context() context.Context {
+	return w.ctx
+}
+
+// StreamServerInterceptor is UnaryServerInterceptor for streaming methods
+func StreamServerInterceptor(v Verifier, opts ...Option) grpc.StreamServerInterceptor {
+	o := newOptions(opts)
+
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := o.authorize(ss.Context(), v, info.FullMethod, tokenFromMetadata(ss.Context()))
+		if err != nil {
+			return grpcError(err)
+		}
+		return handler(srv, &wrappedStream{ServerStream: ss, ctx: ctx})
+	}
+}
@@ -0,0 +1,34 @@
+package authMiddleware
+
+import (
+	"errors"
+	"net/http"
+)
+
+// HTTPMiddleware verifies bearer token from Authorization header and puts
+// Principal into request context. Methods in options are url paths.
+func HTTPMiddleware(v Verifier, opts ...Option) func(http.Handler) http.Handler {
+	o := newOptions(opts)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, err := o.authorize(r.Context(), v, r.URL.Path, bearer(r.Header.Get("Authorization")))
+			if err != nil {
+				switch {
+				case errors.Is(err, ErrForbidden):
+					http.Error(w, ErrForbidden.Error(), http.StatusForbidden)
+				case errors.Is(err, ErrNoToken):
+					w.Header().Set("WWW-Authenticate", "Bearer")
+					http.Error(w, ErrNoToken.Error(), http.StatusUnauthorized)
+				case errors.Is(err, ErrInvalidToken):
+					w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+					http.Error(w, ErrInvalidToken.Error(), http.StatusUnauthorized)
+				default:
+					http.Error(w, "cant verify access token", http.StatusServiceUnavailable)
+				}
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
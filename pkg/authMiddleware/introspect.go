@@ -0,0 +1,54 @@
+package authMiddleware
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/SeiFlow-3P2/auth_service/pkg/authJWT"
+	authv1 "github.com/SeiFlow-3P2/auth_service/pkg/proto/v1"
+	"golang.org/x/oauth2"
+	"google.golang.org/grpc/metadata"
+)
+
+// IntrospectionVerifier asks the auth service about every token. Slower than
+// JWKSVerifier, but notices revoked sessions immediately.
+type IntrospectionVerifier struct {
+	Client authv1.AuthServiceClient
+	// Tokens выдаёт токен сервиса со scope introspect, например
+	// clientcredentials.Config{TokenURL: issuer + "/token", Scopes: []string{"introspect"}}.TokenSource(ctx)
+	Tokens oauth2.TokenSource
+}
+
+// NewIntrospectionVerifier returns verifier that calls Introspect RPC authenticated with tokens
+func NewIntrospectionVerifier(client authv1.AuthServiceClient, tokens oauth2.TokenSource) *IntrospectionVerifier {
+	return &IntrospectionVerifier{Client: client, Tokens: tokens}
+}
+
+// Verify introspects token in the auth service
+func (v *IntrospectionVerifier) Verify(ctx context.Context, token string) (*Principal, error) {
+	// TokenSource из clientcredentials кеширует токен до истечения
+	caller, err := v.Tokens.Token()
+	if err != nil {
+		return nil, fmt.Errorf("introspect: client token: %w", err)
+	}
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+caller.AccessToken)
+
+	resp, err := v.Client.Introspect(ctx, &authv1.IntrospectRequest{Token: token})
+	if err != nil {
+		return nil, fmt.Errorf("introspect: %w", err)
+	}
+	if !resp.GetActive() || resp.GetTokenType() == "refresh" {
+		return nil, ErrInvalidToken
+	}
+
+	return &Principal{
+		UserID:     resp.GetUserId(),
+		Email:      resp.GetEmail(),
+		Username:   resp.GetUsername(),
+		TelegramID: resp.GetTelegramId().GetValue(),
+		SessionID:  resp.GetSessionId(),
+		Scopes:     resp.GetScopes(),
+		ClientID:   resp.GetClientId(),
+		Service:    resp.GetSubType() == authJWT.SubjectTypeService,
+	}, nil
+}
@@ -0,0 +1,85 @@
+package authMiddleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/SeiFlow-3P2/auth_service/pkg/authJWT"
+	"github.com/SeiFlow-3P2/auth_service/pkg/authKeys"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// ErrNoAudience means JWKSVerifier is not configured with this service's audience
+var ErrNoAudience = errors.New("jwks verifier: audience is required")
+
+// JWKSVerifier validates tokens locally with public keys from the auth service JWKS endpoint
+type JWKSVerifier struct {
+	*authKeys.RemoteJWKS
+	// Issuer проверяется, если задан
+	Issuer string
+	// Audience — имя этого сервиса из JWT_AUDIENCE сервиса авторизации, обязательно:
+	// без него сервис принимал бы токены, выданные для любого другого сервиса
+	Audience string
+}
+
+// NewJWKSVerifier returns verifier for jwks url, e.g. http://auth:8080/.well-known/jwks.json,
+// that accepts only tokens issued for audience
+func NewJWKSVerifier(url string, audience string) (*JWKSVerifier, error) {
+	if audience == "" {
+		return nil, ErrNoAudience
+	}
+	return &JWKSVerifier{RemoteJWKS: authKeys.NewRemoteJWKS(url), Audience: audience}, nil
+}
+
+// Verify checks token signature, expiration and audience
+func (v *JWKSVerifier) Verify(ctx context.Context, token string) (*Principal, error) {
+	// Верификатор, собранный без конструктора, не должен молча пропускать проверку audience
+	if v.Audience == "" {
+		return nil, ErrNoAudience
+	}
+
+	claims := &authJWT.Claims{}
+	_, err := jwt.ParseWithClaims(token, claims,
+		func(t *jwt.Token) (interface{}, error) {
+			kid, _ := t.Header["kid"].(string)
+			return v.Key(ctx, kid, t.Method.Alg())
+		},
+		jwt.WithValidMethods([]string{
+			jwt.SigningMethodRS256.Alg(), jwt.SigningMethodES256.Alg(), jwt.SigningMethodEdDSA.Alg(),
+		}),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(30*time.Second),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	// Refresh токены в сервисах не принимаются
+	validator := authJWT.Validator{Issuer: v.Issuer, Audience: []string{v.Audience}, TokenType: authJWT.TokenTypeAccess}
+	if err := validator.Validate(claims); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	return principalFromClaims(claims), nil
+}
+
+func principalFromClaims(claims *authJWT.Claims) *Principal {
+	if claims.IsService() {
+		return &Principal{ClientID: claims.ClientID, Service: true, Scopes: claims.Scopes()}
+	}
+	p := &Principal{
+		UserID:    claims.Subject,
+		Email:     claims.Email,
+		Username:  claims.Username,
+		SessionID: claims.SessionID,
+		Scopes:    claims.Scopes(),
+		ClientID:  claims.ClientID,
+	}
+	if claims.TelegramID != 0 {
+		p.TelegramID = strconv.FormatUint(uint64(claims.TelegramID), 10)
+	}
+	return p
+}
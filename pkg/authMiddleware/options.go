@@ -0,0 +1,118 @@
+package authMiddleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+type options struct {
+	public  map[string]bool
+	scopes  map[string][]string
+	clients map[string]bool
+}
+
+// Option configures middleware
+type Option func(*options)
+
+// WithPublicMethods lets requests to the methods through without token.
+// For gRPC methods are full method names ("/auth_v1.AuthService/Login"),
+// for HTTP url paths, a trailing "*" matches any suffix. A valid token is
+// still verified and injected when present.
+func WithPublicMethods(methods ...string) Option {
+	return func(o *options) {
+		for _, m := range methods {
+			o.public[m] = true
+		}
+	}
+}
+
+// WithRequiredScopes allows method only to principals that have all the scopes
+func WithRequiredScopes(method string, scopes ...string) Option {
+	return func(o *options) {
+		o.scopes[method] = append(o.scopes[method], scopes...)
+	}
+}
+
+// WithClients accepts tokens issued to these OAuth applications (on behalf of a user)
+// and services (client_credentials). Without it only first-party user tokens are accepted.
+func WithClients(clientIDs ...string) Option {
+	return func(o *options) {
+		for _, id := range clientIDs {
+			o.clients[id] = true
+		}
+	}
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{public: make(map[string]bool), scopes: make(map[string][]string), clients: make(map[string]bool)}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+func match(rules map[string]bool, method string) bool {
+	if rules[method] {
+		return true
+	}
+	for rule := range rules {
+		if prefix, ok := strings.CutSuffix(rule, "*"); ok && strings.HasPrefix(method, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func (o *options) requiredScopes(method string) []string {
+	if scopes, ok := o.scopes[method]; ok {
+		return scopes
+	}
+	for rule, scopes := range o.scopes {
+		if prefix, ok := strings.CutSuffix(rule, "*"); ok && strings.HasPrefix(method, prefix) {
+			return scopes
+		}
+	}
+	return nil
+}
+
+// authorize verifies token for the method and returns context with principal
+func (o *options) authorize(ctx context.Context, v Verifier, method string, token string) (context.Context, error) {
+	public := match(o.public, method)
+
+	if token == "" {
+		if public {
+			return ctx, nil
+		}
+		return nil, ErrNoToken
+	}
+
+	p, err := v.Verify(ctx, token)
+	// Токен стороннего приложения не должен работать как токен самого пользователя
+	if err == nil && p.ClientID != "" && !o.clients[p.ClientID] {
+		err = fmt.Errorf("%w: issued to client %q", ErrInvalidToken, p.ClientID)
+	}
+	if err != nil {
+		if public && errors.Is(err, ErrInvalidToken) {
+			return ctx, nil
+		}
+		return nil, err
+	}
+
+	for _, scope := range o.requiredScopes(method) {
+		if !p.HasScope(scope) {
+			return nil, ErrForbidden
+		}
+	}
+	return NewContext(ctx, p), nil
+}
+
+// bearer extracts token from "Bearer <token>" header value
+func bearer(header string) string {
+	token, ok := strings.CutPrefix(header, "Bearer ")
+	if !ok {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
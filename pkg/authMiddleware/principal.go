@@ -0,0 +1,50 @@
+package authMiddleware
+
+import (
+	"context"
+	"errors"
+	"slices"
+)
+
+var (
+	ErrNoToken      = errors.New("no access token")
+	ErrInvalidToken = errors.New("invalid access token")
+	ErrForbidden    = errors.New("insufficient scope")
+)
+
+// Principal is the authenticated caller: a user or a service with client_credentials token.
+// Services have empty UserID, they are identified by ClientID.
+type Principal struct {
+	UserID     string
+	Email      string
+	Username   string
+	TelegramID string
+	SessionID  string
+	Scopes     []string
+	// ClientID приложения, которому пользователь выдал доступ, или самого сервиса
+	ClientID string
+	Service  bool
+}
+
+// HasScope reports whether principal was granted the scope
+func (p *Principal) HasScope(scope string) bool {
+	return slices.Contains(p.Scopes, scope)
+}
+
+type principalKey struct{}
+
+// NewContext returns context carrying principal
+func NewContext(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// FromContext returns principal injected by the middleware
+func FromContext(ctx context.Context) (*Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(*Principal)
+	return p, ok
+}
+
+// Verifier validates access token and returns its principal
+type Verifier interface {
+	Verify(ctx context.Context, token string) (*Principal, error)
+}
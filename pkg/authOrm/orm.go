@@ -13,21 +13,21 @@ type AuthOrm struct {
 }
 
 // CreateUser creates new user in database
-func (d *AuthOrm) CreateUser(name string, email string, photoUrl string, telegramId uint, password []byte) error {
+func (d *AuthOrm) CreateUser(name string, email string, photoUrl string, telegramId uint, passwordHash []byte) error {
 	user := domain.User{
 		ID:           uuid.New(),
 		Username:     name,
 		Email:        email,
 		PhotoUrl:     photoUrl,
 		TelegramId:   telegramId,
-		PasswordHash: password,
+		PasswordHash: passwordHash,
 	}
 	return d.Create(&user).Error
 }
 
-// ChangePassword changes user password
-func (d *AuthOrm) ChangePassword(userId uuid.UUID, password []byte) error {
-	result := d.Model(&domain.User{ID: userId}).Update("password_hash", password).Update("UpdatedAt", time.Now())
+// ChangePassword changes user password hash
+func (d *AuthOrm) ChangePassword(userId uuid.UUID, passwordHash []byte) error {
+	result := d.Model(&domain.User{ID: userId}).Update("password_hash", passwordHash).Update("UpdatedAt", time.Now())
 	if result.Error != nil {
 		return result.Error
 	}
@@ -37,9 +37,25 @@ func (d *AuthOrm) ChangePassword(userId uuid.UUID, password []byte) error {
 	return nil
 }
 
-// ChangeEmail changes user username
+// ChangeEmail changes user email, new email is not verified yet
 func (d *AuthOrm) ChangeEmail(userId uuid.UUID, email string) error {
-	result := d.Model(&domain.User{ID: userId}).Update("email", email).Update("UpdatedAt", time.Now())
+	result := d.Model(&domain.User{ID: userId}).Updates(map[string]interface{}{
+		"email":       email,
+		"verified_at": nil,
+		"updated_at":  time.Now(),
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// ChangeUsername changes user username
+func (d *AuthOrm) ChangeUsername(userId uuid.UUID, username string) error {
+	result := d.Model(&domain.User{ID: userId}).Update("username", username).Update("UpdatedAt", time.Now())
 	if result.Error != nil {
 		return result.Error
 	}
@@ -61,9 +77,52 @@ func (d *AuthOrm) ChangePhoto(userId uuid.UUID, photoUrl string) error {
 	return nil
 }
 
-// ChangeTelegramId changes users telegram id
+// ChangeTelegramId changes users telegram id, new id is not verified
+// and the linked telegram account of the old id is removed
 func (d *AuthOrm) ChangeTelegramId(userId uuid.UUID, telegramId uint) error {
-	result := d.Model(&domain.User{ID: userId}).Update("telegram_id", telegramId).Update("UpdatedAt", time.Now())
+	return d.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&domain.User{ID: userId}).Updates(map[string]interface{}{
+			"telegram_id":       telegramId,
+			"telegram_verified": false,
+			"updated_at":        time.Now(),
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		// Иначе по старому аккаунту telegram можно было бы войти и после смены id
+		return tx.Where("user_id = ? AND provider = ?", userId, domain.ProviderTelegram).Delete(&domain.Identity{}).Error
+	})
+}
+
+// GetUserByTelegramId returns user who confirmed this telegram account
+func (d *AuthOrm) GetUserByTelegramId(telegramId uint) (*domain.User, error) {
+	var user domain.User
+	err := d.First(&user, "telegram_id = ? AND telegram_verified", telegramId).Error
+	return &user, err
+}
+
+// SetTelegramVerified sets telegram id confirmed through Telegram Login Widget
+func (d *AuthOrm) SetTelegramVerified(userId uuid.UUID, telegramId uint) error {
+	result := d.Model(&domain.User{ID: userId}).Updates(map[string]interface{}{
+		"telegram_id":       telegramId,
+		"telegram_verified": true,
+		"updated_at":        time.Now(),
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// SetEmailVerified marks current user email as verified
+func (d *AuthOrm) SetEmailVerified(userId uuid.UUID, verifiedAt time.Time) error {
+	result := d.Model(&domain.User{ID: userId}).Update("verified_at", verifiedAt)
 	if result.Error != nil {
 		return result.Error
 	}
@@ -79,6 +138,13 @@ func (d *AuthOrm) GetUserByEmail(email string) (*domain.User, error) {
 	return &user, err
 }
 
+// GetUserByUsername returns user by username
+func (d *AuthOrm) GetUserByUsername(username string) (*domain.User, error) {
+	var user domain.User
+	err := d.First(&user, "Username = ?", username).Error
+	return &user, err
+}
+
 // GetUser returns user by id
 func (d *AuthOrm) GetUser(userId uuid.UUID) (*domain.User, error) {
 	var user domain.User
@@ -86,6 +152,190 @@ func (d *AuthOrm) GetUser(userId uuid.UUID) (*domain.User, error) {
 	return &user, err
 }
 
+// CreateSecurityEvent saves security event
+func (d *AuthOrm) CreateSecurityEvent(event *domain.SecurityEvent) error {
+	if event.ID == uuid.Nil {
+		event.ID = uuid.New()
+	}
+	return d.Create(event).Error
+}
+
+// GetMFA returns TOTP settings of the user
+func (d *AuthOrm) GetMFA(userId uuid.UUID) (*domain.UserMFA, error) {
+	var mfa domain.UserMFA
+	err := d.First(&mfa, "user_id = ?", userId).Error
+	return &mfa, err
+}
+
+// SaveMFA creates or replaces TOTP settings of the user
+func (d *AuthOrm) SaveMFA(mfa *domain.UserMFA) error {
+	return d.Save(mfa).Error
+}
+
+// UseMFACounter stores time step of accepted code. Returns false if code of
+// this or later step was already used.
+func (d *AuthOrm) UseMFACounter(userId uuid.UUID, counter int64) (bool, error) {
+	result := d.Model(&domain.UserMFA{}).
+		Where("user_id = ? AND last_counter < ?", userId, counter).
+		Update("last_counter", counter)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// DeleteMFA removes TOTP settings and recovery codes of the user
+func (d *AuthOrm) DeleteMFA(userId uuid.UUID) error {
+	return d.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&domain.RecoveryCode{}, "user_id = ?", userId).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&domain.UserMFA{}, "user_id = ?", userId).Error
+	})
+}
+
+// ReplaceRecoveryCodes replaces all recovery codes of the user
+func (d *AuthOrm) ReplaceRecoveryCodes(userId uuid.UUID, codeHashes [][]byte) error {
+	return d.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&domain.RecoveryCode{}, "user_id = ?", userId).Error; err != nil {
+			return err
+		}
+		codes := make([]domain.RecoveryCode, 0, len(codeHashes))
+		for _, hash := range codeHashes {
+			codes = append(codes, domain.RecoveryCode{ID: uuid.New(), UserID: userId, CodeHash: hash})
+		}
+		return tx.Create(&codes).Error
+	})
+}
+
+// UseRecoveryCode marks unused recovery code as used. Returns false if there is no such code.
+func (d *AuthOrm) UseRecoveryCode(userId uuid.UUID, codeHash []byte) (bool, error) {
+	result := d.Model(&domain.RecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userId, codeHash).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// CreateWebAuthnCredential saves new passkey of the user
+func (d *AuthOrm) CreateWebAuthnCredential(credential *domain.WebAuthnCredential) error {
+	if credential.ID == uuid.Nil {
+		credential.ID = uuid.New()
+	}
+	return d.Create(credential).Error
+}
+
+// GetWebAuthnCredentials returns all passkeys of the user
+func (d *AuthOrm) GetWebAuthnCredentials(userId uuid.UUID) ([]domain.WebAuthnCredential, error) {
+	var credentials []domain.WebAuthnCredential
+	err := d.Where("user_id = ?", userId).Order("created_at").Find(&credentials).Error
+	return credentials, err
+}
+
+// DeleteWebAuthnCredential removes passkey of the user
+func (d *AuthOrm) DeleteWebAuthnCredential(userId uuid.UUID, id uuid.UUID) error {
+	result := d.Where("user_id = ?", userId).Delete(&domain.WebAuthnCredential{ID: id})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// UpdateWebAuthnCredentialUsage stores sign counter and backup state after login
+func (d *AuthOrm) UpdateWebAuthnCredentialUsage(id uuid.UUID, signCount uint32, backupState bool, usedAt time.Time) error {
+	return d.Model(&domain.WebAuthnCredential{ID: id}).Updates(map[string]interface{}{
+		"sign_count":   signCount,
+		"backup_state": backupState,
+		"last_used_at": usedAt,
+	}).Error
+}
+
+// CreateIdentity links external account to the user
+func (d *AuthOrm) CreateIdentity(identity *domain.Identity) error {
+	if identity.ID == uuid.Nil {
+		identity.ID = uuid.New()
+	}
+	if identity.LinkedAt.IsZero() {
+		identity.LinkedAt = time.Now()
+	}
+	return d.Create(identity).Error
+}
+
+// GetIdentity returns linked external account by provider and its user id
+func (d *AuthOrm) GetIdentity(provider string, subject string) (*domain.Identity, error) {
+	var identity domain.Identity
+	err := d.First(&identity, "provider = ? AND subject = ?", provider, subject).Error
+	return &identity, err
+}
+
+// GetIdentities returns all external accounts of the user
+func (d *AuthOrm) GetIdentities(userId uuid.UUID) ([]domain.Identity, error) {
+	var identities []domain.Identity
+	err := d.Where("user_id = ?", userId).Order("linked_at").Find(&identities).Error
+	return identities, err
+}
+
+// DeleteIdentity unlinks external account of the user
+func (d *AuthOrm) DeleteIdentity(userId uuid.UUID, id uuid.UUID) error {
+	result := d.Where("user_id = ?", userId).Delete(&domain.Identity{ID: id})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// CreateOAuthClient registers application which logs users in through this service
+func (d *AuthOrm) CreateOAuthClient(client *domain.OAuthClient) error {
+	if client.ID == uuid.Nil {
+		client.ID = uuid.New()
+	}
+	return d.Create(client).Error
+}
+
+// GetOAuthClient returns registered application by its client id
+func (d *AuthOrm) GetOAuthClient(clientID string) (*domain.OAuthClient, error) {
+	var client domain.OAuthClient
+	err := d.First(&client, "client_id = ?", clientID).Error
+	return &client, err
+}
+
+// DeleteOAuthClient removes application together with consents of its users
+func (d *AuthOrm) DeleteOAuthClient(clientID string) error {
+	return d.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("client_id = ?", clientID).Delete(&domain.OAuthClient{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return tx.Where("client_id = ?", clientID).Delete(&domain.OAuthConsent{}).Error
+	})
+}
+
+// GetOAuthConsent returns scopes the user allowed to the application
+func (d *AuthOrm) GetOAuthConsent(userId uuid.UUID, clientID string) (*domain.OAuthConsent, error) {
+	var consent domain.OAuthConsent
+	err := d.First(&consent, "user_id = ? AND client_id = ?", userId, clientID).Error
+	return &consent, err
+}
+
+// SaveOAuthConsent creates or replaces consent of the user
+func (d *AuthOrm) SaveOAuthConsent(consent *domain.OAuthConsent) error {
+	if consent.GrantedAt.IsZero() {
+		consent.GrantedAt = time.Now()
+	}
+	return d.Save(consent).Error
+}
+
 func (d *AuthOrm) Ping() error {
 	db, err := d.DB.DB()
 	if err != nil {
@@ -95,6 +345,6 @@ func (d *AuthOrm) Ping() error {
 }
 
 func (d *AuthOrm) MigrateDB() error {
-	err := d.AutoMigrate(&domain.User{})
+	err := d.AutoMigrate(&domain.User{}, &domain.SecurityEvent{}, &domain.UserMFA{}, &domain.RecoveryCode{}, &domain.WebAuthnCredential{}, &domain.Identity{}, &domain.OAuthClient{}, &domain.OAuthConsent{})
 	return err
 }
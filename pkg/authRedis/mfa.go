@@ -0,0 +1,67 @@
+package authRedis
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-redis/redis"
+)
+
+const (
+	mfaChallengePrefix = "mfa_challenge:"
+	attemptsField      = "attempts"
+)
+
+var ErrChallengeNotFound = errors.New("mfa challenge not found")
+
+func mfaChallengeKey(id string) string {
+	return mfaChallengePrefix + id
+}
+
+// SetMFAChallenge stores login waiting for the second factor
+func (r *Casher) SetMFAChallenge(ctx context.Context, id string, userID string, ttl time.Duration) error {
+	_, err := r.Client.TxPipelined(func(pipe redis.Pipeliner) error {
+		pipe.HMSet(mfaChallengeKey(id), map[string]interface{}{userIDField: userID, attemptsField: 0})
+		pipe.Expire(mfaChallengeKey(id), ttl)
+		return nil
+	})
+	return err
+}
+
+// attemptScript counts attempt of existing challenge, returns {user id, attempts}
+var attemptScript = redis.NewScript(`
+if redis.call("EXISTS", KEYS[1]) == 0 then
+	return false
+end
+local attempts = redis.call("HINCRBY", KEYS[1], ARGV[2], 1)
+return {redis.call("HGET", KEYS[1], ARGV[1]), attempts}
+`)
+
+// MFAChallengeAttempt counts attempt to pass the challenge and returns its user and number of attempts
+func (r *Casher) MFAChallengeAttempt(ctx context.Context, id string) (userID string, attempts int64, err error) {
+	res, err := attemptScript.Run(r.Client, []string{mfaChallengeKey(id)}, userIDField, attemptsField).Result()
+	if errors.Is(err, redis.Nil) {
+		return "", 0, ErrChallengeNotFound
+	}
+	if err != nil {
+		return "", 0, err
+	}
+
+	values, ok := res.([]interface{})
+	if !ok || len(values) != 2 {
+		return "", 0, ErrChallengeNotFound
+	}
+	userID, _ = values[0].(string)
+	attempts, _ = values[1].(int64)
+	return userID, attempts, nil
+}
+
+// DeleteMFAChallenge removes the challenge, returns false if it was already removed
+func (r *Casher) DeleteMFAChallenge(ctx context.Context, id string) (bool, error) {
+	n, err := r.Client.Del(mfaChallengeKey(id)).Result()
+	if err != nil {
+		return false, err
+	}
+	return n == 1, nil
+}
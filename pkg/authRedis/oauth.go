@@ -0,0 +1,47 @@
+package authRedis
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-redis/redis"
+)
+
+const (
+	oauthStatePrefix = "oauth_state:"
+	loginCodePrefix  = "login_code:"
+)
+
+var (
+	ErrOAuthStateNotFound = errors.New("oauth state not found")
+	ErrLoginCodeNotFound  = errors.New("login code not found")
+)
+
+// SetOAuthState stores provider and PKCE verifier of authorization request until the callback
+func (r *Casher) SetOAuthState(ctx context.Context, state string, data []byte, ttl time.Duration) error {
+	return r.Client.Set(oauthStatePrefix+state, data, ttl).Err()
+}
+
+// TakeOAuthState returns data of authorization request, state can be used only once
+func (r *Casher) TakeOAuthState(ctx context.Context, state string) ([]byte, error) {
+	data, err := r.take(oauthStatePrefix + state)
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrOAuthStateNotFound
+	}
+	return data, err
+}
+
+// SetLoginCode stores one-time code which the frontend exchanges for tokens
+func (r *Casher) SetLoginCode(ctx context.Context, codeHash string, userID string, ttl time.Duration) error {
+	return r.Client.Set(loginCodePrefix+codeHash, userID, ttl).Err()
+}
+
+// TakeLoginCode returns user of the login code and deletes it
+func (r *Casher) TakeLoginCode(ctx context.Context, codeHash string) (string, error) {
+	data, err := r.take(loginCodePrefix + codeHash)
+	if errors.Is(err, redis.Nil) {
+		return "", ErrLoginCodeNotFound
+	}
+	return string(data), err
+}
@@ -0,0 +1,56 @@
+package authRedis
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-redis/redis"
+)
+
+const (
+	authorizationRequestPrefix = "oidc_request:"
+	authorizationCodePrefix    = "oidc_code:"
+)
+
+var (
+	ErrAuthorizationRequestNotFound = errors.New("authorization request not found")
+	ErrAuthorizationCodeNotFound    = errors.New("authorization code not found")
+)
+
+// SetAuthorizationRequest stores authorization request of the client until the user logs in and approves it
+func (r *Casher) SetAuthorizationRequest(ctx context.Context, requestID string, data []byte, ttl time.Duration) error {
+	return r.Client.Set(authorizationRequestPrefix+requestID, data, ttl).Err()
+}
+
+// GetAuthorizationRequest returns authorization request, it stays until approved or expired
+func (r *Casher) GetAuthorizationRequest(ctx context.Context, requestID string) ([]byte, error) {
+	data, err := r.Client.Get(authorizationRequestPrefix + requestID).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrAuthorizationRequestNotFound
+	}
+	return data, err
+}
+
+// TakeAuthorizationRequest returns authorization request and deletes it, the user answers only once
+func (r *Casher) TakeAuthorizationRequest(ctx context.Context, requestID string) ([]byte, error) {
+	data, err := r.take(authorizationRequestPrefix + requestID)
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrAuthorizationRequestNotFound
+	}
+	return data, err
+}
+
+// SetAuthorizationCode stores grant behind the authorization code issued to the client
+func (r *Casher) SetAuthorizationCode(ctx context.Context, codeHash string, data []byte, ttl time.Duration) error {
+	return r.Client.Set(authorizationCodePrefix+codeHash, data, ttl).Err()
+}
+
+// TakeAuthorizationCode returns grant of the authorization code, the code can be exchanged only once
+func (r *Casher) TakeAuthorizationCode(ctx context.Context, codeHash string) ([]byte, error) {
+	data, err := r.take(authorizationCodePrefix + codeHash)
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrAuthorizationCodeNotFound
+	}
+	return data, err
+}
@@ -0,0 +1,31 @@
+package authRedis
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-redis/redis"
+)
+
+const usedPrefix = "used:"
+
+// UseOnce marks key as used for ttl, returns false if it was already used.
+// Protects signed one-time data (e.g. Telegram widget payload) from replay.
+func (r *Casher) UseOnce(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	return r.Client.SetNX(usedPrefix+key, 1, ttl).Result()
+}
+
+// take returns value of the key and deletes it in one transaction.
+// Returns redis.Nil if the key does not exist.
+func (r *Casher) take(key string) ([]byte, error) {
+	var get *redis.StringCmd
+	_, err := r.Client.TxPipelined(func(pipe redis.Pipeliner) error {
+		get = pipe.Get(key)
+		pipe.Del(key)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return get.Bytes()
+}
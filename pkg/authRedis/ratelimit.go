@@ -0,0 +1,59 @@
+package authRedis
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis"
+)
+
+const rateLimitPrefix = "rate_limit:"
+
+// tokenBucketScript refills bucket by elapsed time and takes one token.
+// Returns {1, 0} if allowed, otherwise {0, ms to wait}.
+var tokenBucketScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+
+local tokens = tonumber(redis.call("HGET", KEYS[1], "tokens"))
+local ts = tonumber(redis.call("HGET", KEYS[1], "ts"))
+if tokens == nil or ts == nil then
+	tokens = burst
+	ts = now
+end
+tokens = math.min(burst, tokens + math.max(0, now - ts) * rate)
+
+local allowed = 0
+local wait = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+else
+	wait = math.ceil((1 - tokens) / rate)
+end
+
+redis.call("HMSET", KEYS[1], "tokens", tostring(tokens), "ts", now)
+redis.call("PEXPIRE", KEYS[1], math.ceil(burst / rate) + 1000)
+return {allowed, wait}
+`)
+
+// TakeToken takes token from the bucket refilled with rate tokens per second up to burst.
+// Returns false and time until next token if the bucket is empty.
+func (r *Casher) TakeToken(ctx context.Context, key string, rate float64, burst int) (bool, time.Duration, error) {
+	res, err := tokenBucketScript.Run(r.Client, []string{rateLimitPrefix + key},
+		strconv.FormatFloat(rate/1000, 'g', -1, 64), burst, time.Now().UnixMilli(),
+	).Result()
+	if err != nil {
+		return false, 0, err
+	}
+
+	values, ok := res.([]interface{})
+	if !ok || len(values) != 2 {
+		return false, 0, redis.Nil
+	}
+	allowed, _ := values[0].(int64)
+	wait, _ := values[1].(int64)
+	return allowed == 1, time.Duration(wait) * time.Millisecond, nil
+}
@@ -0,0 +1,107 @@
+package authRedis
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-redis/redis"
+)
+
+const (
+	passwordResetPrefix     = "password_reset:"
+	userPasswordResetPrefix = "user_password_reset:"
+)
+
+// maxResetAttempts limits retries of SetPasswordReset racing with another request of the user
+const maxResetAttempts = 5
+
+var (
+	ErrResetTokenNotFound = errors.New("password reset token not found")
+	ErrResetConflict      = errors.New("password reset token is being replaced concurrently")
+)
+
+func passwordResetKey(tokenHash string) string {
+	return passwordResetPrefix + tokenHash
+}
+
+func userPasswordResetKey(userID string) string {
+	return userPasswordResetPrefix + userID
+}
+
+// setResetScript stores new token of the user and deletes the previous one (KEYS[3], if any).
+// Ничего не меняет, если текущий токен пользователя уже не ARGV[1].
+var setResetScript = redis.NewScript(`
+if (redis.call("GET", KEYS[2]) or "") ~= ARGV[1] then
+	return 0
+end
+if KEYS[3] then
+	redis.call("DEL", KEYS[3])
+end
+redis.call("SET", KEYS[1], ARGV[2], "PX", ARGV[4])
+redis.call("SET", KEYS[2], ARGV[3], "PX", ARGV[4])
+return 1
+`)
+
+// takeResetScript deletes token of the user ARGV[1] and the user's link to it
+var takeResetScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) ~= ARGV[1] then
+	return 0
+end
+redis.call("DEL", KEYS[1])
+if redis.call("GET", KEYS[2]) == ARGV[2] then
+	redis.call("DEL", KEYS[2])
+end
+return 1
+`)
+
+// SetPasswordReset stores hash of password reset token for ttl.
+// У пользователя действует только последний выданный токен.
+func (r *Casher) SetPasswordReset(ctx context.Context, userID string, tokenHash string, ttl time.Duration) error {
+	userKey := userPasswordResetKey(userID)
+	// Ключ прошлого токена известен только после чтения, поэтому скрипт сверяет,
+	// что его не заменили, а при гонке пробуем снова
+	for attempt := 0; attempt < maxResetAttempts; attempt++ {
+		prev, err := r.Client.Get(userKey).Result()
+		if err != nil && !errors.Is(err, redis.Nil) {
+			return err
+		}
+		keys := []string{passwordResetKey(tokenHash), userKey}
+		if prev != "" {
+			keys = append(keys, passwordResetKey(prev))
+		}
+
+		res, err := setResetScript.Run(r.Client, keys, prev, userID, tokenHash, ttl.Milliseconds()).Int()
+		if err != nil {
+			return err
+		}
+		if res == 1 {
+			return nil
+		}
+	}
+	return ErrResetConflict
+}
+
+// TakePasswordReset returns user id of the token and deletes the token, so it can be used only once
+func (r *Casher) TakePasswordReset(ctx context.Context, tokenHash string) (string, error) {
+	userID, err := r.Client.Get(passwordResetKey(tokenHash)).Result()
+	if errors.Is(err, redis.Nil) {
+		return "", ErrResetTokenNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+
+	// Токен могли использовать между чтением и скриптом, тогда он уже удалён
+	res, err := takeResetScript.Run(r.Client,
+		[]string{passwordResetKey(tokenHash), userPasswordResetKey(userID)},
+		userID, tokenHash,
+	).Int()
+	if err != nil {
+		return "", err
+	}
+	if res == 0 {
+		return "", ErrResetTokenNotFound
+	}
+	return userID, nil
+}
@@ -3,88 +3,187 @@ package authRedis
 import (
 	"context"
 	"errors"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis"
 )
 
 // Kurinov
 
 const (
-	refreshField = "refresh"
-	passField    = "pass"
+	sessionPrefix      = "session:"
+	userSessionsPrefix = "user_sessions:"
+
+	userIDField     = "user_id"
+	emailField      = "email"
+	refreshIDField  = "refresh_id"
+	userAgentField  = "user_agent"
+	ipField         = "ip"
+	createdAtField  = "created_at"
+	lastUsedAtField = "last_used_at"
 )
 
-// SetSession sets refresh token and pass hash
-func (r *Casher) SetSession(ctx context.Context, userEmail string, refreshToken string, passHash []byte) error {
-
-	setRef := r.Client.HSet(userEmail, refreshField, refreshToken)
-	if setRef.Err() != nil {
-		return setRef.Err()
-	}
+var ErrSessionNotFound = errors.New("session not found")
+
+// Session is a single login of a user on some device
+type Session struct {
+	ID         string
+	UserID     string
+	Email      string
+	RefreshID  string // jti последнего выданного refresh токена
+	UserAgent  string
+	IP         string
+	CreatedAt  time.Time
+	LastUsedAt time.Time
+}
 
-	setPass := r.Client.HSet(userEmail, passField, passHash)
-	if setPass.Err() != nil {
-		return setPass.Err()
-	}
+func sessionKey(sessionID string) string {
+	return sessionPrefix + sessionID
+}
 
-	exp := r.Client.Expire(userEmail, r.RefreshTTL)
-	if exp.Err() != nil {
-		return exp.Err()
+func userSessionsKey(userID string) string {
+	return userSessionsPrefix + userID
+}
 
-	}
-	return nil
+// SetSession creates or updates session and adds it to user's session list
+func (r *Casher) SetSession(ctx context.Context, session Session) error {
+	return r.SetSessionTTL(ctx, session, r.RefreshTTL)
 }
 
-// UserRefresh returns refresh token
-func (r *Casher) UserRefresh(ctx context.Context, userEmail string) (string, error) {
-	var result string
+// SetSessionTTL is SetSession for session that lives ttl instead of refresh token ttl
+func (r *Casher) SetSessionTTL(ctx context.Context, session Session, ttl time.Duration) error {
+	fields := map[string]interface{}{
+		userIDField:     session.UserID,
+		emailField:      session.Email,
+		refreshIDField:  session.RefreshID,
+		userAgentField:  session.UserAgent,
+		ipField:         session.IP,
+		createdAtField:  session.CreatedAt.Unix(),
+		lastUsedAtField: session.LastUsedAt.Unix(),
+	}
 
-	err := r.Client.HGet(userEmail, refreshField).Scan(&result)
+	_, err := r.Client.TxPipelined(func(pipe redis.Pipeliner) error {
+		pipe.HMSet(sessionKey(session.ID), fields)
+		pipe.Expire(sessionKey(session.ID), ttl)
+		pipe.SAdd(userSessionsKey(session.UserID), session.ID)
+		// Индекс живёт, пока жива самая долгая сессия пользователя
+		pipe.Expire(userSessionsKey(session.UserID), r.RefreshTTL)
+		return nil
+	})
+	return err
+}
 
+// rotateScript updates session only if its current refresh id is the expected one
+var rotateScript = redis.NewScript(`
+if redis.call("HGET", KEYS[1], ARGV[1]) ~= ARGV[2] then
+	return 0
+end
+redis.call("HSET", KEYS[1], ARGV[1], ARGV[3])
+redis.call("HSET", KEYS[1], ARGV[4], ARGV[5])
+redis.call("HSET", KEYS[1], ARGV[6], ARGV[7])
+redis.call("HSET", KEYS[1], ARGV[8], ARGV[9])
+redis.call("PEXPIRE", KEYS[1], ARGV[10])
+redis.call("PEXPIRE", KEYS[2], ARGV[10])
+return 1
+`)
+
+// RotateSession atomically replaces refresh id of the session.
+// Returns false if the session was rotated by someone else meanwhile.
+func (r *Casher) RotateSession(ctx context.Context, session Session, prevRefreshID string) (bool, error) {
+	res, err := rotateScript.Run(r.Client,
+		[]string{sessionKey(session.ID), userSessionsKey(session.UserID)},
+		refreshIDField, prevRefreshID, session.RefreshID,
+		userAgentField, session.UserAgent,
+		ipField, session.IP,
+		lastUsedAtField, session.LastUsedAt.Unix(),
+		r.RefreshTTL.Milliseconds(),
+	).Int()
 	if err != nil {
-		return "", err
+		return false, err
 	}
-	return result, nil
-
+	return res == 1, nil
 }
 
-// UserPassHash returns pass hash
-func (r *Casher) UserPassHash(ctx context.Context, userEmail string) (string, error) {
-	var result string
-
-	err := r.Client.HGet(userEmail, passField).Scan(&result)
+// GetSession returns session by id
+func (r *Casher) GetSession(ctx context.Context, sessionID string) (*Session, error) {
+	result, err := r.Client.HGetAll(sessionKey(sessionID)).Result()
+	if err != nil {
+		return nil, err
+	}
+	if len(result) == 0 {
+		return nil, ErrSessionNotFound
+	}
+	return parseSession(sessionID, result), nil
+}
 
+// UserSessions returns all active sessions of the user
+func (r *Casher) UserSessions(ctx context.Context, userID string) ([]Session, error) {
+	ids, err := r.Client.SMembers(userSessionsKey(userID)).Result()
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-	return result, nil
 
+	sessions := make([]Session, 0, len(ids))
+	for _, id := range ids {
+		session, err := r.GetSession(ctx, id)
+		if errors.Is(err, ErrSessionNotFound) {
+			// Сессия истекла сама, чистим индекс
+			r.Client.SRem(userSessionsKey(userID), id)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, *session)
+	}
+	return sessions, nil
 }
 
-// BlockSession deletes user from casher
-func (r *Casher) BlockSession(ctx context.Context, userEmail string) error {
-
-	err := r.Client.HDel(userEmail, refreshField, passField).Err()
+// BlockSession deletes single session from casher
+func (r *Casher) BlockSession(ctx context.Context, userID string, sessionID string) error {
+	_, err := r.Client.TxPipelined(func(pipe redis.Pipeliner) error {
+		pipe.Del(sessionKey(sessionID))
+		pipe.SRem(userSessionsKey(userID), sessionID)
+		return nil
+	})
+	return err
+}
 
+// BlockUserSessions deletes all sessions of the user from casher
+func (r *Casher) BlockUserSessions(ctx context.Context, userID string) error {
+	ids, err := r.Client.SMembers(userSessionsKey(userID)).Result()
 	if err != nil {
 		return err
 	}
-	return nil
-}
 
-// UserSession returns refresh token and pass hash
-func (r *Casher) UserSession(ctx context.Context, userEmail string) (string, string, error) {
-	result, err := r.Client.HGetAll(userEmail).Result()
-	if err != nil {
-		return "", "", err
-	}
+	_, err = r.Client.TxPipelined(func(pipe redis.Pipeliner) error {
+		for _, id := range ids {
+			pipe.Del(sessionKey(id))
+		}
+		pipe.Del(userSessionsKey(userID))
+		return nil
+	})
+	return err
+}
 
-	refresh, ok := result[refreshField]
-	if !ok {
-		return "", "", errors.New("no refresh token")
+func parseSession(sessionID string, fields map[string]string) *Session {
+	return &Session{
+		ID:         sessionID,
+		UserID:     fields[userIDField],
+		Email:      fields[emailField],
+		RefreshID:  fields[refreshIDField],
+		UserAgent:  fields[userAgentField],
+		IP:         fields[ipField],
+		CreatedAt:  parseUnix(fields[createdAtField]),
+		LastUsedAt: parseUnix(fields[lastUsedAtField]),
 	}
+}
 
-	pass, ok := result[passField]
-	if !ok {
-		return "", "", errors.New("no pass hash")
+func parseUnix(value string) time.Time {
+	sec, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return time.Time{}
 	}
-	return refresh, pass, nil
+	return time.Unix(sec, 0)
 }
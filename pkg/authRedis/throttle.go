@@ -0,0 +1,99 @@
+package authRedis
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis"
+)
+
+const (
+	loginFailuresPrefix = "login_failures:"
+	loginLockPrefix     = "login_lock:"
+)
+
+// Throttle keys are "<kind>:<value>", e.g. "email:user@example.com" or "ip:10.0.0.1"
+func loginFailuresKey(key string) string {
+	return loginFailuresPrefix + key
+}
+
+func loginLockKey(key string) string {
+	return loginLockPrefix + key
+}
+
+// loginAttemptScript checks the lock and records the attempt in one step, so parallel
+// logins can't all pass the check before the first of them fails
+var loginAttemptScript = redis.NewScript(`
+local lock = redis.call("PTTL", KEYS[2])
+if lock > 0 then
+	return {0, lock}
+end
+redis.call("ZREMRANGEBYSCORE", KEYS[1], "-inf", ARGV[1])
+local count = redis.call("ZCARD", KEYS[1])
+if count >= tonumber(ARGV[4]) then
+	local oldest = redis.call("ZRANGE", KEYS[1], 0, 0, "WITHSCORES")
+	return {0, math.max(1, math.ceil((tonumber(oldest[2]) - tonumber(ARGV[1])) / 1000000))}
+end
+redis.call("ZADD", KEYS[1], ARGV[2], ARGV[3])
+redis.call("PEXPIRE", KEYS[1], ARGV[5])
+return {count + 1, 0}
+`)
+
+// LoginAttempt records login attempt before the password is checked and returns it with
+// number of attempts within window including this one. Attempt is counted as failure
+// until ForgetLoginAttempt. If login is locked or maxAttempts are already counted nothing
+// is recorded and locked is how long to wait.
+func (r *Casher) LoginAttempt(ctx context.Context, key string, window time.Duration, maxAttempts int) (attempt string, count int64, locked time.Duration, err error) {
+	now := time.Now()
+	suffix := make([]byte, 4)
+	if _, err := rand.Read(suffix); err != nil {
+		return "", 0, 0, err
+	}
+	// Попытки в одну и ту же наносекунду должны считаться отдельно
+	attempt = strconv.FormatInt(now.UnixNano(), 10) + "-" + hex.EncodeToString(suffix)
+
+	result, err := loginAttemptScript.Run(r.Client,
+		[]string{loginFailuresKey(key), loginLockKey(key)},
+		now.Add(-window).UnixNano(), now.UnixNano(), attempt, maxAttempts, window.Milliseconds(),
+	).Result()
+	if err != nil {
+		return "", 0, 0, err
+	}
+	values, ok := result.([]interface{})
+	if !ok || len(values) != 2 {
+		return "", 0, 0, fmt.Errorf("unexpected login attempt result %v", result)
+	}
+	count, _ = values[0].(int64)
+	lockMs, _ := values[1].(int64)
+	if lockMs > 0 {
+		return "", 0, time.Duration(lockMs) * time.Millisecond, nil
+	}
+	return attempt, count, 0, nil
+}
+
+// ForgetLoginAttempt removes attempt that turned out successful from failures of the key
+func (r *Casher) ForgetLoginAttempt(ctx context.Context, key string, attempt string) error {
+	return r.Client.ZRem(loginFailuresKey(key), attempt).Err()
+}
+
+// lockScript sets lock only if it doesn't already last longer
+var lockScript = redis.NewScript(`
+if redis.call("PTTL", KEYS[1]) < tonumber(ARGV[1]) then
+	redis.call("SET", KEYS[1], "1", "PX", ARGV[1])
+end
+return 1
+`)
+
+// LockLogin forbids login attempts for the key during ttl
+func (r *Casher) LockLogin(ctx context.Context, key string, ttl time.Duration) error {
+	return lockScript.Run(r.Client, []string{loginLockKey(key)}, ttl.Milliseconds()).Err()
+}
+
+// ClearLoginFailures removes failures and lock of the key
+func (r *Casher) ClearLoginFailures(ctx context.Context, key string) error {
+	return r.Client.Del(loginFailuresKey(key), loginLockKey(key)).Err()
+}
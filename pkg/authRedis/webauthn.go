@@ -0,0 +1,31 @@
+package authRedis
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-redis/redis"
+)
+
+const webAuthnSessionPrefix = "webauthn_session:"
+
+var ErrWebAuthnSessionNotFound = errors.New("webauthn session not found")
+
+func webAuthnSessionKey(id string) string {
+	return webAuthnSessionPrefix + id
+}
+
+// SetWebAuthnSession stores state of registration or login ceremony
+func (r *Casher) SetWebAuthnSession(ctx context.Context, id string, data []byte, ttl time.Duration) error {
+	return r.Client.Set(webAuthnSessionKey(id), data, ttl).Err()
+}
+
+// TakeWebAuthnSession returns state of the ceremony and deletes it, so it can be finished only once
+func (r *Casher) TakeWebAuthnSession(ctx context.Context, id string) ([]byte, error) {
+	data, err := r.take(webAuthnSessionKey(id))
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrWebAuthnSessionNotFound
+	}
+	return data, err
+}
@@ -0,0 +1,104 @@
+package authTelegram
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Поля, которые присылает Telegram Login Widget, см. https://core.telegram.org/widgets/login
+const (
+	FieldID        = "id"
+	FieldFirstName = "first_name"
+	FieldLastName  = "last_name"
+	FieldUsername  = "username"
+	FieldPhotoURL  = "photo_url"
+	FieldAuthDate  = "auth_date"
+	FieldHash      = "hash"
+)
+
+var (
+	ErrInvalidHash = errors.New("invalid telegram hash")
+	ErrExpired     = errors.New("telegram auth data is too old")
+	ErrInvalidData = errors.New("invalid telegram auth data")
+)
+
+// User is verified Telegram account from the widget
+type User struct {
+	ID        int64
+	FirstName string
+	LastName  string
+	Username  string
+	PhotoURL  string
+	AuthDate  time.Time
+}
+
+// Name returns full name of the user
+func (u *User) Name() string {
+	return strings.TrimSpace(u.FirstName + " " + u.LastName)
+}
+
+// DataCheckString returns all fields except hash sorted by name as key=value lines
+func DataCheckString(fields map[string]string) string {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		if key != FieldHash {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	lines := make([]string, 0, len(keys))
+	for _, key := range keys {
+		lines = append(lines, key+"="+fields[key])
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Hash returns hex HMAC-SHA256 of the data-check-string keyed by SHA256 of the bot token
+func Hash(fields map[string]string, botToken string) string {
+	secret := sha256.Sum256([]byte(botToken))
+	mac := hmac.New(sha256.New, secret[:])
+	mac.Write([]byte(DataCheckString(fields)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks hash and freshness of the widget data and returns the user.
+// now is passed explicitly so data can be checked with a fixed clock.
+func Verify(fields map[string]string, botToken string, maxAge time.Duration, now time.Time) (*User, error) {
+	hash, err := hex.DecodeString(fields[FieldHash])
+	if err != nil || len(hash) == 0 {
+		return nil, ErrInvalidHash
+	}
+	expected, _ := hex.DecodeString(Hash(fields, botToken))
+	if !hmac.Equal(hash, expected) {
+		return nil, ErrInvalidHash
+	}
+
+	authDate, err := strconv.ParseInt(fields[FieldAuthDate], 10, 64)
+	if err != nil {
+		return nil, ErrInvalidData
+	}
+	user := &User{
+		FirstName: fields[FieldFirstName],
+		LastName:  fields[FieldLastName],
+		Username:  fields[FieldUsername],
+		PhotoURL:  fields[FieldPhotoURL],
+		AuthDate:  time.Unix(authDate, 0),
+	}
+	// Небольшое расхождение часов в будущее допустимо
+	if now.Sub(user.AuthDate) > maxAge || user.AuthDate.Sub(now) > time.Minute {
+		return nil, ErrExpired
+	}
+
+	user.ID, err = strconv.ParseInt(fields[FieldID], 10, 64)
+	if err != nil || user.ID <= 0 {
+		return nil, ErrInvalidData
+	}
+	return user, nil
+}
@@ -0,0 +1,142 @@
+package authTelegram
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+)
+
+const testBotToken = "123456:test-bot-token"
+
+var testNow = time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+// signed returns widget data signed with the test bot token, modify changes fields before signing
+func signed(modify func(fields map[string]string)) map[string]string {
+	fields := map[string]string{
+		FieldID:        "42",
+		FieldFirstName: "Ivan",
+		FieldLastName:  "Petrov",
+		FieldUsername:  "ivan",
+		FieldPhotoURL:  "https://t.me/i/userpic/ivan.jpg",
+		FieldAuthDate:  strconv.FormatInt(testNow.Add(-time.Minute).Unix(), 10),
+	}
+	if modify != nil {
+		modify(fields)
+	}
+	fields[FieldHash] = Hash(fields, testBotToken)
+	return fields
+}
+
+func TestVerifyValid(t *testing.T) {
+	user, err := Verify(signed(nil), testBotToken, time.Hour, testNow)
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if user.ID != 42 || user.Username != "ivan" || user.Name() != "Ivan Petrov" {
+		t.Errorf("Verify() user = %+v", user)
+	}
+	if !user.AuthDate.Equal(testNow.Add(-time.Minute)) {
+		t.Errorf("AuthDate = %v, want %v", user.AuthDate, testNow.Add(-time.Minute))
+	}
+}
+
+func TestVerifyInvalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		fields map[string]string
+		want   error
+	}{
+		{
+			name: "tampered field",
+			fields: func() map[string]string {
+				fields := signed(nil)
+				fields[FieldID] = "43"
+				return fields
+			}(),
+			want: ErrInvalidHash,
+		},
+		{
+			name: "other bot token",
+			fields: func() map[string]string {
+				fields := signed(nil)
+				delete(fields, FieldHash)
+				fields[FieldHash] = Hash(fields, "654321:other-bot-token")
+				return fields
+			}(),
+			want: ErrInvalidHash,
+		},
+		{
+			name: "no hash",
+			fields: func() map[string]string {
+				fields := signed(nil)
+				delete(fields, FieldHash)
+				return fields
+			}(),
+			want: ErrInvalidHash,
+		},
+		{
+			name: "stale auth_date",
+			fields: signed(func(fields map[string]string) {
+				fields[FieldAuthDate] = strconv.FormatInt(testNow.Add(-2*time.Hour).Unix(), 10)
+			}),
+			want: ErrExpired,
+		},
+		{
+			name: "future auth_date",
+			fields: signed(func(fields map[string]string) {
+				fields[FieldAuthDate] = strconv.FormatInt(testNow.Add(10*time.Minute).Unix(), 10)
+			}),
+			want: ErrExpired,
+		},
+		{
+			name: "non-numeric auth_date",
+			fields: signed(func(fields map[string]string) {
+				fields[FieldAuthDate] = "yesterday"
+			}),
+			want: ErrInvalidData,
+		},
+		{
+			name: "missing id",
+			fields: signed(func(fields map[string]string) {
+				delete(fields, FieldID)
+			}),
+			want: ErrInvalidData,
+		},
+		{
+			name: "non-numeric id",
+			fields: signed(func(fields map[string]string) {
+				fields[FieldID] = "ivan"
+			}),
+			want: ErrInvalidData,
+		},
+		{
+			name: "negative id",
+			fields: signed(func(fields map[string]string) {
+				fields[FieldID] = "-42"
+			}),
+			want: ErrInvalidData,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user, err := Verify(tt.fields, testBotToken, time.Hour, testNow)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("Verify() error = %v, want %v", err, tt.want)
+			}
+			if user != nil {
+				t.Errorf("Verify() user = %+v, want nil", user)
+			}
+		})
+	}
+}
+
+func TestVerifyClockSkew(t *testing.T) {
+	// Расхождение часов меньше минуты допустимо
+	fields := signed(func(fields map[string]string) {
+		fields[FieldAuthDate] = strconv.FormatInt(testNow.Add(30*time.Second).Unix(), 10)
+	})
+	if _, err := Verify(fields, testBotToken, time.Hour, testNow); err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+}
@@ -0,0 +1,103 @@
+package auth_v1
+
+import (
+	"context"
+	"errors"
+	"github.com/SeiFlow-3P2/auth_service/internal/domain"
+	authv1 "github.com/SeiFlow-3P2/auth_service/pkg/proto/v1"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"time"
+)
+
+// identityError maps service errors of linked accounts to status
+func identityError(err error, msg string) error {
+	switch {
+	case errors.Is(err, domain.ErrUnauthenticated):
+		return status.Error(codes.Unauthenticated, "invalid access token")
+	case errors.Is(err, domain.ErrUnknownProvider):
+		return status.Error(codes.InvalidArgument, "unknown provider")
+	case errors.Is(err, domain.ErrIdentityNotFound):
+		return status.Error(codes.NotFound, "identity not found")
+	case errors.Is(err, domain.ErrIdentityLinked):
+		return status.Error(codes.AlreadyExists, "account is linked to another user")
+	case errors.Is(err, domain.ErrInvalidToken):
+		return status.Error(codes.Unauthenticated, "invalid oauth code")
+	case errors.Is(err, domain.ErrLastLoginMethod):
+		return status.Error(codes.FailedPrecondition, "can't remove the last login method")
+	case errors.Is(err, domain.ErrTelegramDisabled), errors.Is(err, domain.ErrInvalidTelegram):
+		return telegramError(err, msg)
+	}
+	return status.Error(codes.Internal, msg)
+}
+
+func toProtoIdentity(identity *domain.Identity) *authv1.Identity {
+	resp := &authv1.Identity{
+		Provider: identity.Provider,
+		Subject:  identity.Subject,
+		Email:    identity.Email,
+		LinkedAt: identity.LinkedAt.Format(time.RFC3339),
+	}
+	if identity.ID != uuid.Nil {
+		resp.IdentityId = identity.ID.String()
+	}
+	return resp
+}
+
+func (s *serverAPI) ListIdentities(ctx context.Context, in *emptypb.Empty) (*authv1.ListIdentitiesResponse, error) {
+	token, err := accessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	identities, err := s.auth.ListIdentities(ctx, token)
+	if err != nil {
+		return nil, identityError(err, "failed to list identities")
+	}
+
+	resp := &authv1.ListIdentitiesResponse{Identities: make([]*authv1.Identity, 0, len(identities))}
+	for i := range identities {
+		resp.Identities = append(resp.Identities, toProtoIdentity(&identities[i]))
+	}
+	return resp, nil
+}
+
+func (s *serverAPI) LinkIdentity(ctx context.Context, in *authv1.LinkIdentityRequest) (*authv1.Identity, error) {
+	token, err := accessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if in.GetProvider() == "" {
+		return nil, status.Error(codes.InvalidArgument, "no provider")
+	}
+	if in.GetProvider() == domain.ProviderTelegram && len(in.GetTelegramData()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "no telegram auth data")
+	}
+	if in.GetProvider() != domain.ProviderTelegram && in.GetOauthToken() == "" {
+		return nil, status.Error(codes.InvalidArgument, "no oauth token")
+	}
+
+	identity, err := s.auth.LinkIdentity(ctx, token, in.GetProvider(), in.GetOauthToken(), in.GetTelegramData())
+	if err != nil {
+		return nil, identityError(err, "failed to link identity")
+	}
+	return toProtoIdentity(identity), nil
+}
+
+func (s *serverAPI) UnlinkIdentity(ctx context.Context, in *authv1.UnlinkIdentityRequest) (*emptypb.Empty, error) {
+	token, err := accessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	identityID, err := uuid.Parse(in.GetIdentityId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid identity id")
+	}
+
+	if err := s.auth.UnlinkIdentity(ctx, token, identityID); err != nil {
+		return nil, identityError(err, "failed to unlink identity")
+	}
+	return &emptypb.Empty{}, nil
+}
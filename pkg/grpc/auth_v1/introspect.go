@@ -0,0 +1,57 @@
+package auth_v1
+
+import (
+	"context"
+	"errors"
+	"github.com/SeiFlow-3P2/auth_service/internal/domain"
+	authv1 "github.com/SeiFlow-3P2/auth_service/pkg/proto/v1"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"strconv"
+)
+
+func (s *serverAPI) Introspect(ctx context.Context, in *authv1.IntrospectRequest) (*authv1.IntrospectResponse, error) {
+	// Вызывающий сервис передаёт свой токен client_credentials в authorization
+	callerToken, err := accessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if in.GetToken() == "" {
+		return nil, status.Error(codes.InvalidArgument, "no token")
+	}
+
+	info, err := s.auth.Introspect(ctx, callerToken, in.GetToken())
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrUnauthenticated):
+			return nil, status.Error(codes.Unauthenticated, "invalid client token")
+		case errors.Is(err, domain.ErrForbidden):
+			return nil, status.Error(codes.PermissionDenied, "introspect scope required")
+		}
+		return nil, status.Error(codes.Internal, "failed to introspect token")
+	}
+	if !info.Active {
+		return &authv1.IntrospectResponse{Active: false}, nil
+	}
+
+	resp := &authv1.IntrospectResponse{
+		Active:    true,
+		TokenType: info.TokenType,
+		UserId:    info.UserID,
+		Email:     info.Email,
+		Username:  info.Username,
+		Scopes:    info.Scopes,
+		SessionId: info.SessionID,
+		Exp:       info.ExpiresAt.Unix(),
+		ClientId:  info.ClientID,
+		SubType:   info.SubjectType,
+	}
+	if info.TelegramID != 0 {
+		resp.TelegramId = &wrappers.StringValue{Value: strconv.FormatUint(uint64(info.TelegramID), 10)}
+	}
+	if !info.IssuedAt.IsZero() {
+		resp.Iat = info.IssuedAt.Unix()
+	}
+	return resp, nil
+}
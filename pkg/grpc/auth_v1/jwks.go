@@ -0,0 +1,44 @@
+package auth_v1
+
+import (
+	"context"
+	"github.com/SeiFlow-3P2/auth_service/pkg/authKeys"
+	authv1 "github.com/SeiFlow-3P2/auth_service/pkg/proto/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func optional(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+func toProtoJWK(jwk authKeys.JWK) *authv1.JWK {
+	return &authv1.JWK{
+		Kty: jwk.Kty,
+		Kid: jwk.Kid,
+		Use: jwk.Use,
+		Alg: jwk.Alg,
+		N:   optional(jwk.N),
+		E:   optional(jwk.E),
+		Crv: optional(jwk.Crv),
+		X:   optional(jwk.X),
+		Y:   optional(jwk.Y),
+	}
+}
+
+func (s *serverAPI) GetJWKS(ctx context.Context, in *emptypb.Empty) (*authv1.JWKSResponse, error) {
+	jwks, err := s.auth.GetJWKS(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get jwks")
+	}
+
+	resp := &authv1.JWKSResponse{Keys: make([]*authv1.JWK, 0, len(jwks.Keys))}
+	for _, jwk := range jwks.Keys {
+		resp.Keys = append(resp.Keys, toProtoJWK(jwk))
+	}
+	return resp, nil
+}
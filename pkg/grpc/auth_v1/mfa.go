@@ -0,0 +1,116 @@
+package auth_v1
+
+import (
+	"context"
+	"errors"
+	"github.com/SeiFlow-3P2/auth_service/internal/domain"
+	authv1 "github.com/SeiFlow-3P2/auth_service/pkg/proto/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// mfaError maps service errors of MFA management to status
+func mfaError(err error, msg string) error {
+	switch {
+	case errors.Is(err, domain.ErrUnauthenticated):
+		return status.Error(codes.Unauthenticated, "invalid access token")
+	case errors.Is(err, domain.ErrInvalidPassword):
+		return status.Error(codes.PermissionDenied, "invalid password")
+	case errors.Is(err, domain.ErrInvalidMFACode):
+		return status.Error(codes.PermissionDenied, "invalid code")
+	case errors.Is(err, domain.ErrMFAEnabled):
+		return status.Error(codes.FailedPrecondition, "mfa already enabled")
+	case errors.Is(err, domain.ErrMFANotEnabled):
+		return status.Error(codes.FailedPrecondition, "mfa not enabled")
+	}
+	return status.Error(codes.Internal, msg)
+}
+
+// mfaRequired returns login response with MFA challenge if err is *domain.MFARequiredError
+func mfaRequired(err error) (*authv1.LoginResponse, bool) {
+	var required *domain.MFARequiredError
+	if !errors.As(err, &required) {
+		return nil, false
+	}
+	return &authv1.LoginResponse{
+		UserId:       required.UserID.String(),
+		MfaRequired:  true,
+		MfaChallenge: required.Challenge,
+	}, true
+}
+
+func (s *serverAPI) EnrollMFA(ctx context.Context, in *emptypb.Empty) (*authv1.EnrollMFAResponse, error) {
+	token, err := accessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, uri, err := s.auth.EnrollMFA(ctx, token)
+	if err != nil {
+		return nil, mfaError(err, "failed to enroll mfa")
+	}
+	return &authv1.EnrollMFAResponse{Secret: secret, OtpauthUri: uri}, nil
+}
+
+func (s *serverAPI) ConfirmMFA(ctx context.Context, in *authv1.ConfirmMFARequest) (*authv1.ConfirmMFAResponse, error) {
+	token, err := accessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if in.GetCode() == "" {
+		return nil, status.Error(codes.InvalidArgument, "no code")
+	}
+
+	recoveryCodes, err := s.auth.ConfirmMFA(ctx, token, in.GetCode())
+	if err != nil {
+		return nil, mfaError(err, "failed to confirm mfa")
+	}
+	return &authv1.ConfirmMFAResponse{RecoveryCodes: recoveryCodes}, nil
+}
+
+func (s *serverAPI) DisableMFA(ctx context.Context, in *authv1.DisableMFARequest) (*emptypb.Empty, error) {
+	token, err := accessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if in.GetCode() == "" {
+		return nil, status.Error(codes.InvalidArgument, "no code")
+	}
+
+	if err := s.auth.DisableMFA(ctx, token, []byte(in.GetPassword()), in.GetCode()); err != nil {
+		if err := retryError(ctx, err); err != nil {
+			return nil, err
+		}
+		return nil, mfaError(err, "failed to disable mfa")
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *serverAPI) VerifyMFA(ctx context.Context, in *authv1.VerifyMFARequest) (*authv1.LoginResponse, error) {
+	if passkey := in.GetPasskey(); passkey != nil && in.GetMfaChallenge() != "" {
+		userID, accessToken, refreshToken, err := s.auth.VerifyMFAPasskey(ctx, in.GetMfaChallenge(), passkey.GetSessionId(), []byte(passkey.GetCredential()))
+		if err != nil {
+			return nil, passkeyError(err, "failed to verify mfa")
+		}
+		return &authv1.LoginResponse{UserId: userID.String(), AccessToken: accessToken, RefreshToken: refreshToken}, nil
+	}
+	if in.GetMfaChallenge() == "" || in.GetCode() == "" {
+		return nil, status.Error(codes.InvalidArgument, "no challenge or code")
+	}
+
+	userID, accessToken, refreshToken, err := s.auth.VerifyMFA(ctx, in.GetMfaChallenge(), in.GetCode())
+	if err != nil {
+		if err := retryError(ctx, err); err != nil {
+			return nil, err
+		}
+		switch {
+		case errors.Is(err, domain.ErrInvalidToken):
+			return nil, status.Error(codes.Unauthenticated, "invalid or expired mfa challenge")
+		case errors.Is(err, domain.ErrInvalidMFACode):
+			return nil, status.Error(codes.PermissionDenied, "invalid code")
+		}
+		return nil, status.Error(codes.Internal, "failed to verify mfa")
+	}
+	return &authv1.LoginResponse{UserId: userID.String(), AccessToken: accessToken, RefreshToken: refreshToken}, nil
+}
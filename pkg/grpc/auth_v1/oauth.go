@@ -0,0 +1,25 @@
+package auth_v1
+
+import (
+	"context"
+	"errors"
+	"github.com/SeiFlow-3P2/auth_service/internal/domain"
+	authv1 "github.com/SeiFlow-3P2/auth_service/pkg/proto/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *serverAPI) ExchangeLoginCode(ctx context.Context, in *authv1.ExchangeLoginCodeRequest) (*authv1.LoginResponse, error) {
+	if in.GetCode() == "" {
+		return nil, status.Error(codes.InvalidArgument, "no code")
+	}
+
+	userID, accessToken, refreshToken, err := s.auth.ExchangeLoginCode(ctx, in.GetCode())
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidToken) {
+			return nil, status.Error(codes.Unauthenticated, "invalid or expired login code")
+		}
+		return nil, status.Error(codes.Internal, "failed to exchange login code")
+	}
+	return &authv1.LoginResponse{UserId: userID.String(), AccessToken: accessToken, RefreshToken: refreshToken}, nil
+}
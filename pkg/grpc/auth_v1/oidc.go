@@ -0,0 +1,135 @@
+package auth_v1
+
+import (
+	"context"
+	"errors"
+	"github.com/SeiFlow-3P2/auth_service/internal/domain"
+	authv1 "github.com/SeiFlow-3P2/auth_service/pkg/proto/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"time"
+)
+
+// oidcError maps service errors of registered applications and their authorization requests to status
+func oidcError(err error, msg string) error {
+	switch {
+	case errors.Is(err, domain.ErrUnauthenticated):
+		return status.Error(codes.Unauthenticated, "invalid access token")
+	case errors.Is(err, domain.ErrForbidden):
+		return status.Error(codes.PermissionDenied, "admin only")
+	case errors.Is(err, domain.ErrClientMetadata):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, domain.ErrInvalidClient):
+		return status.Error(codes.NotFound, "client not found")
+	case errors.Is(err, domain.ErrRequestNotFound):
+		return status.Error(codes.NotFound, "authorization request not found or expired")
+	}
+	return status.Error(codes.Internal, msg)
+}
+
+func (s *serverAPI) RegisterOAuthClient(ctx context.Context, in *authv1.RegisterOAuthClientRequest) (*authv1.RegisterOAuthClientResponse, error) {
+	token, err := accessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if in.GetName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "no name")
+	}
+	if in.GetTokenTtlSeconds() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid token ttl")
+	}
+
+	client, secret, err := s.auth.RegisterOAuthClient(ctx, token, domain.ClientRegistration{
+		Name:         in.GetName(),
+		RedirectURIs: in.GetRedirectUris(),
+		Scopes:       in.GetScopes(),
+		GrantTypes:   in.GetGrantTypes(),
+		Public:       in.GetPublic(),
+		SkipConsent:  in.GetSkipConsent(),
+		TokenTTL:     time.Duration(in.GetTokenTtlSeconds()) * time.Second,
+	})
+	if err != nil {
+		return nil, oidcError(err, "failed to register client")
+	}
+	return &authv1.RegisterOAuthClientResponse{ClientId: client.ClientID, ClientSecret: secret}, nil
+}
+
+func (s *serverAPI) DeleteOAuthClient(ctx context.Context, in *authv1.DeleteOAuthClientRequest) (*emptypb.Empty, error) {
+	token, err := accessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if in.GetClientId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "no client id")
+	}
+
+	if err := s.auth.DeleteOAuthClient(ctx, token, in.GetClientId()); err != nil {
+		return nil, oidcError(err, "failed to delete client")
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *serverAPI) GetAuthorizationRequest(ctx context.Context, in *authv1.GetAuthorizationRequestRequest) (*authv1.AuthorizationRequest, error) {
+	token, err := accessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if in.GetRequestId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "no request id")
+	}
+
+	request, err := s.auth.GetAuthorizationRequest(ctx, token, in.GetRequestId())
+	if err != nil {
+		return nil, oidcError(err, "failed to get authorization request")
+	}
+	return &authv1.AuthorizationRequest{
+		ClientId:        request.ClientID,
+		ClientName:      request.ClientName,
+		Scopes:          request.Scopes,
+		RedirectUri:     request.RedirectURI,
+		ConsentRequired: request.ConsentRequired,
+	}, nil
+}
+
+func (s *serverAPI) ApproveAuthorization(ctx context.Context, in *authv1.ApproveAuthorizationRequest) (*authv1.ApproveAuthorizationResponse, error) {
+	token, err := accessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if in.GetRequestId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "no request id")
+	}
+
+	redirectURI, err := s.auth.ApproveAuthorization(ctx, token, in.GetRequestId(), in.GetApprove())
+	if err != nil {
+		return nil, oidcError(err, "failed to approve authorization")
+	}
+	return &authv1.ApproveAuthorizationResponse{RedirectUri: redirectURI}, nil
+}
+
+func (s *serverAPI) IssueClientToken(ctx context.Context, in *authv1.IssueClientTokenRequest) (*authv1.IssueClientTokenResponse, error) {
+	if in.GetClientId() == "" || in.GetClientSecret() == "" {
+		return nil, status.Error(codes.InvalidArgument, "client id and secret required")
+	}
+
+	tokens, err := s.auth.IssueClientToken(ctx, in.GetClientId(), in.GetClientSecret(), in.GetScopes())
+	if err != nil {
+		var oauthErr *domain.OAuthError
+		switch {
+		case errors.Is(err, domain.ErrInvalidClient):
+			return nil, status.Error(codes.Unauthenticated, "invalid client credentials")
+		case errors.As(err, &oauthErr) && oauthErr.Code == "invalid_scope":
+			return nil, status.Error(codes.InvalidArgument, oauthErr.Description)
+		case errors.As(err, &oauthErr):
+			return nil, status.Error(codes.PermissionDenied, oauthErr.Description)
+		}
+		return nil, status.Error(codes.Internal, "failed to issue client token")
+	}
+	return &authv1.IssueClientTokenResponse{
+		AccessToken: tokens.AccessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   int64(tokens.ExpiresIn.Seconds()),
+		Scopes:      tokens.Scopes,
+	}, nil
+}
@@ -0,0 +1,125 @@
+package auth_v1
+
+import (
+	"context"
+	"errors"
+	"github.com/SeiFlow-3P2/auth_service/internal/domain"
+	authv1 "github.com/SeiFlow-3P2/auth_service/pkg/proto/v1"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"time"
+)
+
+// passkeyError maps service errors of WebAuthn ceremonies to status
+func passkeyError(err error, msg string) error {
+	switch {
+	case errors.Is(err, domain.ErrWebAuthnDisabled):
+		return status.Error(codes.Unimplemented, "passkeys are not configured")
+	case errors.Is(err, domain.ErrUnauthenticated):
+		return status.Error(codes.Unauthenticated, "invalid access token")
+	case errors.Is(err, domain.ErrInvalidToken):
+		return status.Error(codes.Unauthenticated, "invalid or expired passkey session")
+	case errors.Is(err, domain.ErrInvalidPasskey):
+		return status.Error(codes.PermissionDenied, "invalid passkey")
+	case errors.Is(err, domain.ErrEmailNotVerified):
+		return status.Error(codes.FailedPrecondition, "email not verified")
+	case errors.Is(err, domain.ErrReauthRequired):
+		return status.Error(codes.FailedPrecondition, "log in again or pass password and mfa code")
+	case errors.Is(err, domain.ErrInvalidPassword):
+		return status.Error(codes.PermissionDenied, "invalid password")
+	case errors.Is(err, domain.ErrInvalidMFACode):
+		return status.Error(codes.PermissionDenied, "invalid code")
+	case errors.Is(err, domain.ErrPasskeyNotFound):
+		return status.Error(codes.NotFound, "passkey not found")
+	case errors.Is(err, domain.ErrLastLoginMethod):
+		return status.Error(codes.FailedPrecondition, "can't remove the last login method")
+	}
+	return status.Error(codes.Internal, msg)
+}
+
+func (s *serverAPI) BeginPasskeyRegistration(ctx context.Context, in *authv1.BeginPasskeyRegistrationRequest) (*authv1.BeginPasskeyResponse, error) {
+	token, err := accessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sessionID, options, err := s.auth.BeginPasskeyRegistration(ctx, token, []byte(in.GetPassword()), in.GetCode())
+	if err != nil {
+		if err := retryError(ctx, err); err != nil {
+			return nil, err
+		}
+		return nil, passkeyError(err, "failed to begin passkey registration")
+	}
+	return &authv1.BeginPasskeyResponse{SessionId: sessionID, Options: string(options)}, nil
+}
+
+func (s *serverAPI) FinishPasskeyRegistration(ctx context.Context, in *authv1.FinishPasskeyRegistrationRequest) (*authv1.FinishPasskeyRegistrationResponse, error) {
+	token, err := accessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if in.GetSessionId() == "" || in.GetCredential() == "" {
+		return nil, status.Error(codes.InvalidArgument, "no session id or credential")
+	}
+
+	id, err := s.auth.FinishPasskeyRegistration(ctx, token, in.GetSessionId(), in.GetName(), []byte(in.GetCredential()))
+	if err != nil {
+		return nil, passkeyError(err, "failed to register passkey")
+	}
+	return &authv1.FinishPasskeyRegistrationResponse{PasskeyId: id.String()}, nil
+}
+
+func (s *serverAPI) ListPasskeys(ctx context.Context, in *emptypb.Empty) (*authv1.ListPasskeysResponse, error) {
+	token, err := accessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	passkeys, err := s.auth.ListPasskeys(ctx, token)
+	if err != nil {
+		return nil, passkeyError(err, "failed to list passkeys")
+	}
+
+	resp := &authv1.ListPasskeysResponse{Passkeys: make([]*authv1.Passkey, 0, len(passkeys))}
+	for _, p := range passkeys {
+		passkey := &authv1.Passkey{
+			PasskeyId: p.ID.String(),
+			Name:      p.Name,
+			CreatedAt: p.CreatedAt.Format(time.RFC3339),
+		}
+		if p.LastUsedAt != nil {
+			passkey.LastUsedAt = p.LastUsedAt.Format(time.RFC3339)
+		}
+		resp.Passkeys = append(resp.Passkeys, passkey)
+	}
+	return resp, nil
+}
+
+func (s *serverAPI) DeletePasskey(ctx context.Context, in *authv1.DeletePasskeyRequest) (*emptypb.Empty, error) {
+	token, err := accessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	passkeyID, err := uuid.Parse(in.GetPasskeyId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid passkey id")
+	}
+
+	if err := s.auth.DeletePasskey(ctx, token, passkeyID, []byte(in.GetPassword()), in.GetCode()); err != nil {
+		if err := retryError(ctx, err); err != nil {
+			return nil, err
+		}
+		return nil, passkeyError(err, "failed to delete passkey")
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *serverAPI) BeginPasskeyLogin(ctx context.Context, in *authv1.BeginPasskeyLoginRequest) (*authv1.BeginPasskeyResponse, error) {
+	sessionID, options, err := s.auth.BeginPasskeyLogin(ctx, in.GetMfaChallenge())
+	if err != nil {
+		return nil, passkeyError(err, "failed to begin passkey login")
+	}
+	return &authv1.BeginPasskeyResponse{SessionId: sessionID, Options: string(options)}, nil
+}
@@ -0,0 +1,118 @@
+package auth_v1
+
+import (
+	"context"
+	"errors"
+	"github.com/SeiFlow-3P2/auth_service/internal/domain"
+	authv1 "github.com/SeiFlow-3P2/auth_service/pkg/proto/v1"
+	verfic "github.com/SeiFlow-3P2/auth_service/pkg/utils/verifications"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"strconv"
+)
+
+// profileError maps service errors of profile changes to status
+func profileError(err error, msg string) error {
+	switch {
+	case errors.Is(err, domain.ErrUnauthenticated):
+		return status.Error(codes.Unauthenticated, "invalid access token")
+	case errors.Is(err, domain.ErrInvalidPassword):
+		return status.Error(codes.PermissionDenied, "invalid password")
+	case errors.Is(err, domain.ErrEmailTaken):
+		return status.Error(codes.AlreadyExists, "email already taken")
+	case errors.Is(err, domain.ErrUsernameTaken):
+		return status.Error(codes.AlreadyExists, "username already taken")
+	case errors.Is(err, domain.ErrLastLoginMethod):
+		return status.Error(codes.FailedPrecondition, "can't unlink telegram, it is the last login method")
+	}
+	return status.Error(codes.Internal, msg)
+}
+
+func (s *serverAPI) ChangePassword(ctx context.Context, in *authv1.ChangePasswordRequest) (*emptypb.Empty, error) {
+	token, err := accessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if in.GetCurrentPassword() == "" || in.GetNewPassword() == "" {
+		return nil, status.Error(codes.InvalidArgument, "No password")
+	}
+
+	err = s.auth.ChangePassword(ctx, token, []byte(in.GetCurrentPassword()), []byte(in.GetNewPassword()))
+	if err != nil {
+		return nil, profileError(err, "failed to change password")
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *serverAPI) ChangeEmail(ctx context.Context, in *authv1.ChangeEmailRequest) (*emptypb.Empty, error) {
+	token, err := accessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	valid, err := verfic.VerifyEmail(in.GetNewEmail())
+	if err != nil || !valid {
+		return nil, status.Error(codes.InvalidArgument, "invalid email")
+	}
+	if in.GetPassword() == "" {
+		return nil, status.Error(codes.InvalidArgument, "No password")
+	}
+
+	if err := s.auth.ChangeEmail(ctx, token, []byte(in.GetPassword()), in.GetNewEmail()); err != nil {
+		return nil, profileError(err, "failed to change email")
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *serverAPI) UpdateProfile(ctx context.Context, in *authv1.UpdateProfileRequest) (*authv1.UpdateProfileResponse, error) {
+	token, err := accessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var update domain.ProfileUpdate
+	if in.Username != nil {
+		if in.Username.Value == "" {
+			return nil, status.Error(codes.InvalidArgument, "empty username")
+		}
+		update.Username = &in.Username.Value
+	}
+	if in.PhotoUrl != nil {
+		update.PhotoUrl = &in.PhotoUrl.Value
+	}
+	if in.TelegramId != nil {
+		var telegramID uint
+		if in.TelegramId.Value != "" {
+			id, err := strconv.ParseUint(in.TelegramId.Value, 10, 64)
+			if err != nil {
+				return nil, status.Error(codes.InvalidArgument, "invalid telegram id")
+			}
+			telegramID = uint(id)
+		}
+		update.TelegramID = &telegramID
+	}
+
+	user, err := s.auth.UpdateProfile(ctx, token, update)
+	if err != nil {
+		return nil, profileError(err, "failed to update profile")
+	}
+	return &authv1.UpdateProfileResponse{User: toProtoUser(user)}, nil
+}
+
+func toProtoUser(user *domain.User) *authv1.UserInfo {
+	info := &authv1.UserInfo{
+		Id:        user.ID.String(),
+		Username:  user.Username,
+		Email:     user.Email,
+		CreatedAt: user.CreatedAt.String(),
+		UpdatedAt: user.UpdatedAt.String(),
+	}
+	if user.TelegramId != 0 {
+		info.TelegramId = &wrappers.StringValue{Value: strconv.FormatUint(uint64(user.TelegramId), 10)}
+	}
+	if user.PhotoUrl != "" {
+		info.PhotoUrl = &wrappers.StringValue{Value: user.PhotoUrl}
+	}
+	return info
+}
@@ -0,0 +1,42 @@
+package auth_v1
+
+import (
+	"context"
+	"errors"
+	"github.com/SeiFlow-3P2/auth_service/internal/domain"
+	authv1 "github.com/SeiFlow-3P2/auth_service/pkg/proto/v1"
+	verfic "github.com/SeiFlow-3P2/auth_service/pkg/utils/verifications"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (s *serverAPI) RequestPasswordReset(ctx context.Context, in *authv1.RequestPasswordResetRequest) (*emptypb.Empty, error) {
+	valid, err := verfic.VerifyEmail(in.GetEmail())
+	if err != nil || !valid {
+		return nil, status.Error(codes.InvalidArgument, "invalid email")
+	}
+
+	if err := s.auth.RequestPasswordReset(ctx, in.GetEmail()); err != nil {
+		return nil, status.Error(codes.Internal, "failed to request password reset")
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *serverAPI) ResetPassword(ctx context.Context, in *authv1.ResetPasswordRequest) (*emptypb.Empty, error) {
+	if in.GetToken() == "" {
+		return nil, status.Error(codes.InvalidArgument, "no token")
+	}
+	if in.GetNewPassword() == "" {
+		return nil, status.Error(codes.InvalidArgument, "No password")
+	}
+
+	err := s.auth.ResetPassword(ctx, in.GetToken(), []byte(in.GetNewPassword()))
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidToken) {
+			return nil, status.Error(codes.InvalidArgument, "invalid or expired reset token")
+		}
+		return nil, status.Error(codes.Internal, "failed to reset password")
+	}
+	return &emptypb.Empty{}, nil
+}
@@ -2,15 +2,23 @@ package auth_v1
 
 import (
 	"context"
+	"errors"
+	"github.com/SeiFlow-3P2/auth_service/internal/domain"
+	"github.com/SeiFlow-3P2/auth_service/pkg/authKeys"
+	"github.com/SeiFlow-3P2/auth_service/pkg/authRedis"
 	authv1 "github.com/SeiFlow-3P2/auth_service/pkg/proto/v1"
 	verfic "github.com/SeiFlow-3P2/auth_service/pkg/utils/verifications"
 	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 	"strconv"
+	"strings"
+	"time"
 )
 
 type serverAPI struct {
@@ -45,8 +53,8 @@ type Auth interface {
 	) (accessToken string, refreshToken string, err error)
 
 	Logout(ctx context.Context,
-		userID uuid.UUID) (err error)
-	UserInfo(ctx context.Context, userID uuid.UUID) (
+		accessToken string) (err error)
+	UserInfo(ctx context.Context, accessToken string, userID uuid.UUID) (
 		id string,
 		telegramId uint,
 		username string,
@@ -55,20 +63,86 @@ type Auth interface {
 		createdAt string,
 		updatedAt string, err error)
 	HealthCheck(ctx context.Context) (status string, err error)
+
+	ListSessions(ctx context.Context, accessToken string) (sessions []authRedis.Session, currentSessionID string, err error)
+	RevokeSession(ctx context.Context, accessToken string, sessionID string) (err error)
+	RevokeAllSessions(ctx context.Context, accessToken string, keepCurrent bool) (err error)
+
+	GetJWKS(ctx context.Context) (jwks authKeys.JWKS, err error)
+
+	Introspect(ctx context.Context, callerToken string, token string) (info domain.TokenInfo, err error)
+
+	VerifyEmail(ctx context.Context, token string) (userID uuid.UUID, verifiedAt time.Time, err error)
+	ResendVerification(ctx context.Context, email string) (err error)
+
+	RequestPasswordReset(ctx context.Context, email string) (err error)
+	ResetPassword(ctx context.Context, token string, password []byte) (err error)
+
+	ChangePassword(ctx context.Context, accessToken string, currentPassword []byte, newPassword []byte) (err error)
+	ChangeEmail(ctx context.Context, accessToken string, password []byte, newEmail string) (err error)
+	UpdateProfile(ctx context.Context, accessToken string, update domain.ProfileUpdate) (user *domain.User, err error)
+
+	ClearLoginLockout(ctx context.Context, accessToken string, email string, ip string) (err error)
+
+	EnrollMFA(ctx context.Context, accessToken string) (secret string, uri string, err error)
+	ConfirmMFA(ctx context.Context, accessToken string, code string) (recoveryCodes []string, err error)
+	DisableMFA(ctx context.Context, accessToken string, password []byte, code string) (err error)
+	VerifyMFA(ctx context.Context, challenge string, code string) (userID uuid.UUID, accessToken string, refreshToken string, err error)
+
+	BeginPasskeyRegistration(ctx context.Context, accessToken string, password []byte, code string) (sessionID string, options []byte, err error)
+	FinishPasskeyRegistration(ctx context.Context, accessToken string, sessionID string, name string, credential []byte) (credentialID uuid.UUID, err error)
+	ListPasskeys(ctx context.Context, accessToken string) (passkeys []domain.WebAuthnCredential, err error)
+	DeletePasskey(ctx context.Context, accessToken string, passkeyID uuid.UUID, password []byte, code string) (err error)
+	BeginPasskeyLogin(ctx context.Context, mfaChallenge string) (sessionID string, options []byte, err error)
+	LoginByPasskey(ctx context.Context, sessionID string, credential []byte) (userID uuid.UUID, accessToken string, refreshToken string, message string, err error)
+	VerifyMFAPasskey(ctx context.Context, challenge string, sessionID string, credential []byte) (userID uuid.UUID, accessToken string, refreshToken string, err error)
+
+	LoginByTelegram(ctx context.Context, fields map[string]string) (userID uuid.UUID, accessToken string, refreshToken string, message string, err error)
+	LinkTelegram(ctx context.Context, accessToken string, fields map[string]string) (err error)
+
+	ListIdentities(ctx context.Context, accessToken string) (identities []domain.Identity, err error)
+	LinkIdentity(ctx context.Context, accessToken string, provider string, oauthToken string, telegramData map[string]string) (identity *domain.Identity, err error)
+	UnlinkIdentity(ctx context.Context, accessToken string, identityID uuid.UUID) (err error)
+
+	ExchangeLoginCode(ctx context.Context, code string) (userID uuid.UUID, accessToken string, refreshToken string, err error)
+
+	RegisterOAuthClient(ctx context.Context, accessToken string, registration domain.ClientRegistration) (client *domain.OAuthClient, secret string, err error)
+	DeleteOAuthClient(ctx context.Context, accessToken string, clientID string) (err error)
+	GetAuthorizationRequest(ctx context.Context, accessToken string, requestID string) (request *domain.AuthorizationRequest, err error)
+	ApproveAuthorization(ctx context.Context, accessToken string, requestID string, approve bool) (redirectURI string, err error)
+	IssueClientToken(ctx context.Context, clientID string, clientSecret string, scopes []string) (tokens domain.OIDCTokens, err error)
 }
 
 func Register(gRPCServer *grpc.Server, auth Auth) {
 	authv1.RegisterAuthServiceServer(gRPCServer, &serverAPI{auth: auth})
 }
+
+// accessToken returns bearer token from authorization metadata
+func accessToken(ctx context.Context) (string, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", status.Error(codes.Unauthenticated, "no metadata")
+	}
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return "", status.Error(codes.Unauthenticated, "no access token")
+	}
+	token, found := strings.CutPrefix(values[0], "Bearer ")
+	if !found || token == "" {
+		return "", status.Error(codes.Unauthenticated, "invalid authorization header")
+	}
+	return token, nil
+}
+
 func (s *serverAPI) Logout(ctx context.Context, in *authv1.LogoutRequest) (*emptypb.Empty, error) {
-	userId, err := uuid.Parse(in.GetUserId()) //TODO Дождаться обновления протофайла и сравнить, работает ли?
+	token, err := accessToken(ctx)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, "invalid user id")
+		return nil, err
 	}
 
-	err = s.auth.Logout(ctx, userId)
+	err = s.auth.Logout(ctx, token)
 	if err != nil {
-		return nil, status.Error(codes.Internal, "failed to logout")
+		return nil, sessionError(err, "failed to logout")
 	}
 
 	return &emptypb.Empty{}, nil
@@ -79,13 +153,57 @@ func (s *serverAPI) RefreshToken(ctx context.Context, in *authv1.RefreshTokenReq
 	}
 	accessToken, refreshToken, err := s.auth.RefreshToken(ctx, in.GetRefreshToken())
 	if err != nil {
-		return nil, status.Error(codes.Internal, "failed to refresh token "+err.Error())
+		switch {
+		case errors.Is(err, domain.ErrTokenReused):
+			st, _ := status.New(codes.PermissionDenied, "refresh token reused, session revoked").
+				WithDetails(&errdetails.ErrorInfo{Reason: "REFRESH_TOKEN_REUSED", Domain: "auth_service"})
+			return nil, st.Err()
+		case errors.Is(err, domain.ErrInvalidToken):
+			return nil, status.Error(codes.Unauthenticated, "invalid refresh token")
+		}
+		return nil, status.Error(codes.Internal, "failed to refresh token")
 	}
 	return &authv1.RefreshTokenResponse{AccessToken: accessToken, RefreshToken: refreshToken}, nil
 }
 func (s *serverAPI) Login(ctx context.Context, in *authv1.LoginRequest) (*authv1.LoginResponse, error) {
-	if in.GetOauth() != nil {
-		panic("not implemented")
+	if oAuth := in.GetOauth(); oAuth != nil {
+		if oAuth.Provider == "" || oAuth.OauthToken == "" {
+			return nil, status.Error(codes.InvalidArgument, "invalid oauth")
+		}
+		userID, accessToken, refreshToken, message, err := s.auth.LoginByOauth(ctx, oAuth.Provider, oAuth.OauthToken)
+		if resp, ok := mfaRequired(err); ok {
+			return resp, nil
+		}
+		if errors.Is(err, domain.ErrAccountExists) {
+			return nil, status.Error(codes.FailedPrecondition, "account with this email exists, log in to it and link the provider")
+		}
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, "failed to login")
+		}
+		return &authv1.LoginResponse{UserId: userID.String(), AccessToken: accessToken, RefreshToken: refreshToken, Message: message}, nil
+	}
+	if passkey := in.GetPasskey(); passkey != nil {
+		if passkey.SessionId == "" || passkey.Credential == "" {
+			return nil, status.Error(codes.InvalidArgument, "invalid passkey")
+		}
+		userID, accessToken, refreshToken, message, err := s.auth.LoginByPasskey(ctx, passkey.SessionId, []byte(passkey.Credential))
+		if err != nil {
+			return nil, passkeyError(err, "failed to login")
+		}
+		return &authv1.LoginResponse{UserId: userID.String(), AccessToken: accessToken, RefreshToken: refreshToken, Message: message}, nil
+	}
+	if telegram := in.GetTelegram(); telegram != nil {
+		if len(telegram.Data) == 0 {
+			return nil, status.Error(codes.InvalidArgument, "invalid telegram")
+		}
+		userID, accessToken, refreshToken, message, err := s.auth.LoginByTelegram(ctx, telegram.Data)
+		if resp, ok := mfaRequired(err); ok {
+			return resp, nil
+		}
+		if err != nil {
+			return nil, telegramError(err, "failed to login")
+		}
+		return &authv1.LoginResponse{UserId: userID.String(), AccessToken: accessToken, RefreshToken: refreshToken, Message: message}, nil
 	}
 	if in.GetEmail() != nil {
 
@@ -99,7 +217,16 @@ func (s *serverAPI) Login(ctx context.Context, in *authv1.LoginRequest) (*authv1
 
 		}
 		userID, accessToken, refreshToken, message, err := s.auth.LoginByEmail(ctx, in.GetEmail().Email, []byte(in.GetEmail().Password))
+		if resp, ok := mfaRequired(err); ok {
+			return resp, nil
+		}
 		if err != nil {
+			if err := retryError(ctx, err); err != nil {
+				return nil, err
+			}
+			if errors.Is(err, domain.ErrEmailNotVerified) {
+				return nil, status.Error(codes.FailedPrecondition, "email not verified")
+			}
 			return nil, status.Error(codes.Internal, "failed to login")
 		}
 		usrID := userID.String()
@@ -142,7 +269,20 @@ func (s *serverAPI) SignUp(ctx context.Context, in *authv1.SignUpRequest) (*auth
 		return &authv1.SignUpResponse{UserId: userID.String(), AccessToken: accessToken, RefreshToken: refreshToken, Message: message}, nil
 
 	} else if oAuth != nil {
-		userID, accessToken, refreshToken, message, err := s.auth.SingUpByOauth(ctx, oAuth.Provider, oAuth.OauthToken, oAuth.TelegramId.Value)
+		if oAuth.Provider == "" || oAuth.OauthToken == "" {
+			return nil, status.Error(codes.InvalidArgument, "invalid oauth")
+		}
+		userID, accessToken, refreshToken, message, err := s.auth.SingUpByOauth(ctx, oAuth.Provider, oAuth.OauthToken, oAuth.GetTelegramId().GetValue())
+		if _, ok := mfaRequired(err); ok {
+			// Аккаунт уже существует и защищён вторым фактором
+			return nil, status.Error(codes.FailedPrecondition, "account exists and requires mfa, use Login")
+		}
+		if errors.Is(err, domain.ErrAccountExists) {
+			return nil, status.Error(codes.FailedPrecondition, "account with this email exists, log in to it and link the provider")
+		}
+		if errors.Is(err, domain.ErrInvalidToken) {
+			return nil, status.Error(codes.Unauthenticated, "invalid oauth code")
+		}
 		if err != nil {
 			return nil, status.Error(codes.Internal, "failed to sing up")
 		}
@@ -157,6 +297,10 @@ func (s *serverAPI) GetUserInfo(
 	in *authv1.GetUserInfoRequest,
 ) (*authv1.GetUserInfoResponse, error) {
 
+	token, err := accessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
 	if in.UserId == "" {
 		return nil, status.Error(codes.InvalidArgument, "No user id")
 	}
@@ -164,8 +308,14 @@ func (s *serverAPI) GetUserInfo(
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, "invalid user id")
 	}
-	id, telegramId, username, email, photoUrl, createdAt, updatedAt, err := s.auth.UserInfo(ctx, userID)
+	id, telegramId, username, email, photoUrl, createdAt, updatedAt, err := s.auth.UserInfo(ctx, token, userID)
 	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrUnauthenticated):
+			return nil, status.Error(codes.Unauthenticated, "invalid access token")
+		case errors.Is(err, domain.ErrForbidden):
+			return nil, status.Error(codes.PermissionDenied, "only own profile, admin or users scope")
+		}
 		return nil, status.Error(codes.Internal, "failed to get user info")
 	}
 	return &authv1.GetUserInfoResponse{User: &authv1.UserInfo{Id: id, TelegramId: &wrappers.StringValue{Value: strconv.Itoa(int(telegramId))}, Username: username, Email: email,
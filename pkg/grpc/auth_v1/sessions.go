@@ -0,0 +1,78 @@
+package auth_v1
+
+import (
+	"context"
+	"errors"
+	"github.com/SeiFlow-3P2/auth_service/internal/domain"
+	authv1 "github.com/SeiFlow-3P2/auth_service/pkg/proto/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"time"
+)
+
+// sessionError maps service errors to gRPC status
+func sessionError(err error, msg string) error {
+	switch {
+	case errors.Is(err, domain.ErrUnauthenticated):
+		return status.Error(codes.Unauthenticated, "invalid access token")
+	case errors.Is(err, domain.ErrSessionNotFound):
+		return status.Error(codes.NotFound, "session not found")
+	default:
+		return status.Error(codes.Internal, msg)
+	}
+}
+
+func (s *serverAPI) ListSessions(ctx context.Context, in *authv1.ListSessionsRequest) (*authv1.ListSessionsResponse, error) {
+	token, err := accessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions, currentID, err := s.auth.ListSessions(ctx, token)
+	if err != nil {
+		return nil, sessionError(err, "failed to list sessions")
+	}
+
+	resp := &authv1.ListSessionsResponse{Sessions: make([]*authv1.Session, 0, len(sessions))}
+	for _, session := range sessions {
+		resp.Sessions = append(resp.Sessions, &authv1.Session{
+			SessionId:  session.ID,
+			UserAgent:  session.UserAgent,
+			Ip:         session.IP,
+			CreatedAt:  session.CreatedAt.Format(time.RFC3339),
+			LastUsedAt: session.LastUsedAt.Format(time.RFC3339),
+			Current:    session.ID == currentID,
+		})
+	}
+	return resp, nil
+}
+
+func (s *serverAPI) RevokeSession(ctx context.Context, in *authv1.RevokeSessionRequest) (*emptypb.Empty, error) {
+	token, err := accessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if in.GetSessionId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "no session id")
+	}
+
+	err = s.auth.RevokeSession(ctx, token, in.GetSessionId())
+	if err != nil {
+		return nil, sessionError(err, "failed to revoke session")
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *serverAPI) RevokeAllSessions(ctx context.Context, in *authv1.RevokeAllSessionsRequest) (*emptypb.Empty, error) {
+	token, err := accessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.auth.RevokeAllSessions(ctx, token, in.GetKeepCurrent())
+	if err != nil {
+		return nil, sessionError(err, "failed to revoke sessions")
+	}
+	return &emptypb.Empty{}, nil
+}
@@ -0,0 +1,41 @@
+package auth_v1
+
+import (
+	"context"
+	"errors"
+	"github.com/SeiFlow-3P2/auth_service/internal/domain"
+	authv1 "github.com/SeiFlow-3P2/auth_service/pkg/proto/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// telegramError maps service errors of Telegram login to status
+func telegramError(err error, msg string) error {
+	switch {
+	case errors.Is(err, domain.ErrTelegramDisabled):
+		return status.Error(codes.Unimplemented, "telegram login is not configured")
+	case errors.Is(err, domain.ErrUnauthenticated):
+		return status.Error(codes.Unauthenticated, "invalid access token")
+	case errors.Is(err, domain.ErrInvalidTelegram):
+		return status.Error(codes.Unauthenticated, "invalid or expired telegram auth data")
+	case errors.Is(err, domain.ErrIdentityLinked):
+		return status.Error(codes.AlreadyExists, "telegram account is linked to another user")
+	}
+	return status.Error(codes.Internal, msg)
+}
+
+func (s *serverAPI) LinkTelegram(ctx context.Context, in *authv1.LinkTelegramRequest) (*emptypb.Empty, error) {
+	token, err := accessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(in.GetData()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "no telegram auth data")
+	}
+
+	if err := s.auth.LinkTelegram(ctx, token, in.GetData()); err != nil {
+		return nil, telegramError(err, "failed to link telegram")
+	}
+	return &emptypb.Empty{}, nil
+}
@@ -0,0 +1,59 @@
+package auth_v1
+
+import (
+	"context"
+	"errors"
+	"github.com/SeiFlow-3P2/auth_service/internal/domain"
+	authv1 "github.com/SeiFlow-3P2/auth_service/pkg/proto/v1"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"math"
+	"net"
+	"strconv"
+)
+
+// retryError converts *domain.RetryError to ResourceExhausted with retry-after
+// header (seconds) and RetryInfo detail. Returns nil for other errors.
+func retryError(ctx context.Context, err error) error {
+	var retry *domain.RetryError
+	if !errors.As(err, &retry) {
+		return nil
+	}
+
+	seconds := int64(math.Ceil(retry.RetryAfter.Seconds()))
+	_ = grpc.SetHeader(ctx, metadata.Pairs("retry-after", strconv.FormatInt(seconds, 10)))
+
+	st, _ := status.New(codes.ResourceExhausted, "too many attempts, try again later").
+		WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(retry.RetryAfter)})
+	return st.Err()
+}
+
+func (s *serverAPI) ClearLoginLockout(ctx context.Context, in *authv1.ClearLoginLockoutRequest) (*emptypb.Empty, error) {
+	token, err := accessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if in.GetEmail() == "" && in.GetIp() == "" {
+		return nil, status.Error(codes.InvalidArgument, "email or ip required")
+	}
+	if in.GetIp() != "" && net.ParseIP(in.GetIp()) == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid ip")
+	}
+
+	err = s.auth.ClearLoginLockout(ctx, token, in.GetEmail(), in.GetIp())
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrUnauthenticated):
+			return nil, status.Error(codes.Unauthenticated, "invalid access token")
+		case errors.Is(err, domain.ErrForbidden):
+			return nil, status.Error(codes.PermissionDenied, "admin only")
+		}
+		return nil, status.Error(codes.Internal, "failed to clear login lockout")
+	}
+	return &emptypb.Empty{}, nil
+}
@@ -0,0 +1,40 @@
+package auth_v1
+
+import (
+	"context"
+	"errors"
+	"github.com/SeiFlow-3P2/auth_service/internal/domain"
+	authv1 "github.com/SeiFlow-3P2/auth_service/pkg/proto/v1"
+	verfic "github.com/SeiFlow-3P2/auth_service/pkg/utils/verifications"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"time"
+)
+
+func (s *serverAPI) VerifyEmail(ctx context.Context, in *authv1.VerifyEmailRequest) (*authv1.VerifyEmailResponse, error) {
+	if in.GetToken() == "" {
+		return nil, status.Error(codes.InvalidArgument, "no token")
+	}
+
+	userID, verifiedAt, err := s.auth.VerifyEmail(ctx, in.GetToken())
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidToken) {
+			return nil, status.Error(codes.InvalidArgument, "invalid or expired verification token")
+		}
+		return nil, status.Error(codes.Internal, "failed to verify email")
+	}
+	return &authv1.VerifyEmailResponse{UserId: userID.String(), VerifiedAt: verifiedAt.Format(time.RFC3339)}, nil
+}
+
+func (s *serverAPI) ResendVerification(ctx context.Context, in *authv1.ResendVerificationRequest) (*emptypb.Empty, error) {
+	valid, err := verfic.VerifyEmail(in.GetEmail())
+	if err != nil || !valid {
+		return nil, status.Error(codes.InvalidArgument, "invalid email")
+	}
+
+	if err := s.auth.ResendVerification(ctx, in.GetEmail()); err != nil {
+		return nil, status.Error(codes.Internal, "failed to send verification email")
+	}
+	return &emptypb.Empty{}, nil
+}
@@ -0,0 +1,305 @@
+package authOauth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/SeiFlow-3P2/auth_service/internal/domain"
+	"github.com/SeiFlow-3P2/auth_service/pkg/authKeys"
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/oauth2"
+)
+
+var ErrNoIDToken = errors.New("provider did not return id token")
+
+// ClaimMapping names ID token claims for domain.UserInfo fields, empty names take standard claims
+type ClaimMapping struct {
+	ID            string
+	Name          string
+	Email         string
+	EmailVerified string
+	AvatarURL     string
+	// TrustEmail считает email подтверждённым, если IdP не передаёт email_verified (часто у корпоративных IdP)
+	TrustEmail bool
+}
+
+func (m ClaimMapping) withDefaults() ClaimMapping {
+	def := func(value *string, name string) {
+		if *value == "" {
+			*value = name
+		}
+	}
+	def(&m.ID, "sub")
+	def(&m.Name, "name")
+	def(&m.Email, "email")
+	def(&m.EmailVerified, "email_verified")
+	def(&m.AvatarURL, "picture")
+	return m
+}
+
+// OIDCConfig describes OpenID Connect provider configured by issuer URL
+type OIDCConfig struct {
+	Name         string
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	// Scopes по умолчанию openid email profile
+	Scopes []string
+	Claims ClaimMapping
+	// Client используется для discovery, JWKS и обмена кода, например для локального тестового издателя
+	Client *http.Client
+}
+
+// discovery is the part of /.well-known/openid-configuration we use
+type discovery struct {
+	Issuer                string   `json:"issuer"`
+	AuthorizationEndpoint string   `json:"authorization_endpoint"`
+	TokenEndpoint         string   `json:"token_endpoint"`
+	UserinfoEndpoint      string   `json:"userinfo_endpoint"`
+	JWKSURI               string   `json:"jwks_uri"`
+	SigningAlgs           []string `json:"id_token_signing_alg_values_supported"`
+}
+
+// OIDC is OpenID Connect provider. User is taken from the ID token verified with provider JWKS.
+type OIDC struct {
+	Name        string
+	Issuer      string
+	Config      *oauth2.Config
+	Claims      ClaimMapping
+	UserInfoURL string
+	Client      *http.Client
+
+	keys *authKeys.RemoteJWKS
+	algs []string
+}
+
+// DiscoverOIDC loads provider metadata from the issuer discovery document
+func DiscoverOIDC(ctx context.Context, cfg OIDCConfig) (*OIDC, error) {
+	client := cfg.Client
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	var doc discovery
+	url := strings.TrimSuffix(cfg.Issuer, "/") + "/.well-known/openid-configuration"
+	if err := getJSON(ctx, client, url, &doc); err != nil {
+		return nil, err
+	}
+	// Издатель из документа должен совпадать с настроенным, иначе токены подписаны кем-то другим
+	if doc.Issuer != cfg.Issuer {
+		return nil, fmt.Errorf("%s: issuer %q does not match %q", cfg.Name, doc.Issuer, cfg.Issuer)
+	}
+	if doc.AuthorizationEndpoint == "" || doc.TokenEndpoint == "" || doc.JWKSURI == "" {
+		return nil, fmt.Errorf("%s: incomplete discovery document", cfg.Name)
+	}
+
+	scopes := cfg.Scopes
+	if len(scopes) == 0 {
+		scopes = []string{"openid", "email", "profile"}
+	}
+	algs := doc.SigningAlgs
+	if len(algs) == 0 {
+		algs = []string{"RS256"}
+	}
+
+	keys := authKeys.NewRemoteJWKS(doc.JWKSURI)
+	keys.Client = client
+
+	return &OIDC{
+		Name:   cfg.Name,
+		Issuer: doc.Issuer,
+		Config: &oauth2.Config{
+			ClientID:     cfg.ClientID,
+			ClientSecret: cfg.ClientSecret,
+			RedirectURL:  cfg.RedirectURL,
+			Scopes:       scopes,
+			Endpoint:     oauth2.Endpoint{AuthURL: doc.AuthorizationEndpoint, TokenURL: doc.TokenEndpoint},
+		},
+		Claims:      cfg.Claims.withDefaults(),
+		UserInfoURL: doc.UserinfoEndpoint,
+		Client:      client,
+		keys:        keys,
+		algs:        algs,
+	}, nil
+}
+
+// LazyOIDC is OpenID Connect provider discovered on first use. Failed discovery is
+// retried on later requests, so IdP unavailable at startup starts working when it's back.
+type LazyOIDC struct {
+	Config OIDCConfig
+	// RetryInterval не даёт обращаться к недоступному издателю на каждый запрос
+	RetryInterval time.Duration
+
+	mu          sync.Mutex
+	provider    *OIDC
+	attemptedAt time.Time
+	err         error
+}
+
+// NewLazyOIDC returns provider that is discovered when it is needed
+func NewLazyOIDC(cfg OIDCConfig) *LazyOIDC {
+	return &LazyOIDC{Config: cfg, RetryInterval: 30 * time.Second}
+}
+
+// Provider returns discovered provider, discovering it if it wasn't yet
+func (l *LazyOIDC) Provider(ctx context.Context) (*OIDC, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.provider != nil {
+		return l.provider, nil
+	}
+	if !l.attemptedAt.IsZero() && time.Since(l.attemptedAt) < l.RetryInterval {
+		return nil, l.err
+	}
+	l.attemptedAt = time.Now()
+	l.provider, l.err = DiscoverOIDC(ctx, l.Config)
+	return l.provider, l.err
+}
+
+// Ready discovers the provider, BeginOAuth calls it before AuthCodeURL
+func (l *LazyOIDC) Ready(ctx context.Context) error {
+	_, err := l.Provider(ctx)
+	return err
+}
+
+// AuthCodeURL returns URL of the provider consent page, empty until the provider is discovered
+func (l *LazyOIDC) AuthCodeURL(state string, opts ...oauth2.AuthCodeOption) string {
+	l.mu.Lock()
+	provider := l.provider
+	l.mu.Unlock()
+	if provider == nil {
+		return ""
+	}
+	return provider.AuthCodeURL(state, opts...)
+}
+
+// Exchange exchanges authorization code for provider tokens
+func (l *LazyOIDC) Exchange(ctx context.Context, code string, opts ...oauth2.AuthCodeOption) (*oauth2.Token, error) {
+	provider, err := l.Provider(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return provider.Exchange(ctx, code, opts...)
+}
+
+// UserInfo maps claims of the ID token from provider response to user info
+func (l *LazyOIDC) UserInfo(ctx context.Context, token *oauth2.Token) (*domain.UserInfo, error) {
+	provider, err := l.Provider(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return provider.UserInfo(ctx, token)
+}
+
+func (p *OIDC) context(ctx context.Context) context.Context {
+	return context.WithValue(ctx, oauth2.HTTPClient, p.Client)
+}
+
+// AuthCodeURL returns URL of the provider consent page
+func (p *OIDC) AuthCodeURL(state string, opts ...oauth2.AuthCodeOption) string {
+	return p.Config.AuthCodeURL(state, opts...)
+}
+
+// Exchange exchanges authorization code for provider tokens
+func (p *OIDC) Exchange(ctx context.Context, code string, opts ...oauth2.AuthCodeOption) (*oauth2.Token, error) {
+	return p.Config.Exchange(p.context(ctx), code, opts...)
+}
+
+// VerifyIDToken checks signature, issuer, audience and expiration of the ID token
+func (p *OIDC) VerifyIDToken(ctx context.Context, rawToken string) (jwt.MapClaims, error) {
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(rawToken, claims,
+		func(t *jwt.Token) (interface{}, error) {
+			kid, _ := t.Header["kid"].(string)
+			return p.keys.Key(ctx, kid, t.Method.Alg())
+		},
+		jwt.WithValidMethods(p.algs),
+		jwt.WithIssuer(p.Issuer),
+		jwt.WithAudience(p.Config.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(time.Minute),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid id token: %w", p.Name, err)
+	}
+
+	// Токен с несколькими получателями должен быть выдан именно нам (OIDC Core 3.1.3.7)
+	aud, _ := claims.GetAudience()
+	if azp, ok := claims["azp"].(string); len(aud) > 1 && (!ok || azp != p.Config.ClientID) {
+		return nil, fmt.Errorf("%s: id token issued to %q", p.Name, azp)
+	}
+	return claims, nil
+}
+
+// UserInfo maps claims of the ID token from provider response to user info.
+// Claims missing in the ID token are taken from the userinfo endpoint.
+func (p *OIDC) UserInfo(ctx context.Context, token *oauth2.Token) (*domain.UserInfo, error) {
+	rawToken, _ := token.Extra("id_token").(string)
+	if rawToken == "" {
+		return nil, ErrNoIDToken
+	}
+	claims, err := p.VerifyIDToken(ctx, rawToken)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := claims[p.Claims.Email]; !ok && p.UserInfoURL != "" {
+		extra := jwt.MapClaims{}
+		client := p.Config.Client(p.context(ctx), token)
+		if err := getJSON(ctx, client, p.UserInfoURL, &extra); err != nil {
+			return nil, err
+		}
+		// Ответ userinfo должен относиться к тому же пользователю, что и ID токен
+		if extra["sub"] != claims["sub"] {
+			return nil, fmt.Errorf("%s: userinfo subject mismatch", p.Name)
+		}
+		for key, value := range extra {
+			if _, ok := claims[key]; !ok {
+				claims[key] = value
+			}
+		}
+	}
+
+	info := &domain.UserInfo{
+		ID:        claimString(claims, p.Claims.ID),
+		Name:      claimString(claims, p.Claims.Name),
+		Email:     claimString(claims, p.Claims.Email),
+		AvatarURL: claimString(claims, p.Claims.AvatarURL),
+		Provider:  p.Name,
+	}
+	switch verified := claims[p.Claims.EmailVerified].(type) {
+	case bool:
+		info.EmailVerified = verified
+	case string:
+		// Некоторые IdP отдают "true" строкой
+		info.EmailVerified = verified == "true"
+	case nil:
+		info.EmailVerified = p.Claims.TrustEmail
+	}
+	if info.ID == "" {
+		return nil, fmt.Errorf("%s: no %s claim", p.Name, p.Claims.ID)
+	}
+	if info.Email == "" || !info.EmailVerified {
+		return nil, ErrEmailNotVerified
+	}
+	return info, nil
+}
+
+// claimString returns claim as string, numeric ids are formatted without exponent
+func claimString(claims jwt.MapClaims, name string) string {
+	switch value := claims[name].(type) {
+	case string:
+		return value
+	case float64:
+		return fmt.Sprintf("%.0f", value)
+	}
+	return ""
+}
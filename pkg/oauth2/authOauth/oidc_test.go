@@ -0,0 +1,233 @@
+package authOauth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/SeiFlow-3P2/auth_service/pkg/authKeys"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const testClientID = "auth-service"
+
+// fakeIssuer is OpenID Connect provider serving discovery document and JWKS of one key
+type fakeIssuer struct {
+	*httptest.Server
+	key *authKeys.Key
+	// issuer можно подменить, чтобы документ описывал другого издателя
+	issuer string
+	// down отвечает 503 на все запросы
+	down atomic.Bool
+}
+
+func newFakeIssuer(t *testing.T) *fakeIssuer {
+	t.Helper()
+	key, err := authKeys.GenerateKey(jwt.SigningMethodRS256.Alg())
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+	f := &fakeIssuer{key: key}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"issuer":                                f.issuer,
+			"authorization_endpoint":                f.URL + "/authorize",
+			"token_endpoint":                        f.URL + "/token",
+			"jwks_uri":                              f.URL + "/jwks",
+			"id_token_signing_alg_values_supported": []string{"RS256"},
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		jwk, err := f.key.JWK()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(authKeys.JWKS{Keys: []authKeys.JWK{jwk}})
+	})
+	f.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if f.down.Load() {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	}))
+	f.issuer = f.URL
+	t.Cleanup(f.Close)
+	return f
+}
+
+func (f *fakeIssuer) config() OIDCConfig {
+	return OIDCConfig{Name: "fake", Issuer: f.URL, ClientID: testClientID, Client: f.Client()}
+}
+
+// sign returns ID token signed by key, modify changes claims before signing
+func (f *fakeIssuer) sign(t *testing.T, key *authKeys.Key, modify func(claims jwt.MapClaims)) string {
+	t.Helper()
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"iss":   f.URL,
+		"sub":   "user-1",
+		"aud":   testClientID,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+		"email": "user@example.com",
+	}
+	if modify != nil {
+		modify(claims)
+	}
+	token := jwt.NewWithClaims(key.Method, claims)
+	token.Header["kid"] = key.ID
+	raw, err := token.SignedString(key.Private)
+	if err != nil {
+		t.Fatalf("SignedString() error = %v", err)
+	}
+	return raw
+}
+
+func TestDiscoverOIDC(t *testing.T) {
+	f := newFakeIssuer(t)
+
+	provider, err := DiscoverOIDC(context.Background(), f.config())
+	if err != nil {
+		t.Fatalf("DiscoverOIDC() error = %v", err)
+	}
+	if provider.Config.Endpoint.TokenURL != f.URL+"/token" {
+		t.Errorf("TokenURL = %q, want %q", provider.Config.Endpoint.TokenURL, f.URL+"/token")
+	}
+	if !strings.HasPrefix(provider.AuthCodeURL("state"), f.URL+"/authorize?") {
+		t.Errorf("AuthCodeURL() = %q", provider.AuthCodeURL("state"))
+	}
+}
+
+func TestDiscoverOIDCWrongIssuer(t *testing.T) {
+	f := newFakeIssuer(t)
+	f.issuer = "https://evil.example"
+
+	if _, err := DiscoverOIDC(context.Background(), f.config()); err == nil {
+		t.Fatal("DiscoverOIDC() accepted document of another issuer")
+	}
+}
+
+func TestVerifyIDToken(t *testing.T) {
+	f := newFakeIssuer(t)
+	provider, err := DiscoverOIDC(context.Background(), f.config())
+	if err != nil {
+		t.Fatalf("DiscoverOIDC() error = %v", err)
+	}
+	otherKey, err := authKeys.GenerateKey(jwt.SigningMethodRS256.Alg())
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr bool
+	}{
+		{
+			name:  "valid",
+			token: f.sign(t, f.key, nil),
+		},
+		{
+			name: "multiple audiences with azp",
+			token: f.sign(t, f.key, func(claims jwt.MapClaims) {
+				claims["aud"] = []string{testClientID, "other-client"}
+				claims["azp"] = testClientID
+			}),
+		},
+		{
+			name: "wrong iss",
+			token: f.sign(t, f.key, func(claims jwt.MapClaims) {
+				claims["iss"] = "https://evil.example"
+			}),
+			wantErr: true,
+		},
+		{
+			name: "wrong aud",
+			token: f.sign(t, f.key, func(claims jwt.MapClaims) {
+				claims["aud"] = "other-client"
+			}),
+			wantErr: true,
+		},
+		{
+			name: "multiple audiences without azp",
+			token: f.sign(t, f.key, func(claims jwt.MapClaims) {
+				claims["aud"] = []string{testClientID, "other-client"}
+			}),
+			wantErr: true,
+		},
+		{
+			name: "multiple audiences with azp of another client",
+			token: f.sign(t, f.key, func(claims jwt.MapClaims) {
+				claims["aud"] = []string{testClientID, "other-client"}
+				claims["azp"] = "other-client"
+			}),
+			wantErr: true,
+		},
+		{
+			name:    "unknown kid",
+			token:   f.sign(t, otherKey, nil),
+			wantErr: true,
+		},
+		{
+			name: "expired",
+			token: f.sign(t, f.key, func(claims jwt.MapClaims) {
+				claims["exp"] = time.Now().Add(-time.Hour).Unix()
+			}),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := provider.VerifyIDToken(context.Background(), tt.token)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("VerifyIDToken() accepted token, claims = %v", claims)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("VerifyIDToken() error = %v", err)
+			}
+			if claims["sub"] != "user-1" {
+				t.Errorf("sub = %v, want user-1", claims["sub"])
+			}
+		})
+	}
+}
+
+func TestLazyOIDCRetriesDiscovery(t *testing.T) {
+	f := newFakeIssuer(t)
+	f.down.Store(true)
+	provider := NewLazyOIDC(f.config())
+	provider.RetryInterval = time.Hour
+
+	if err := provider.Ready(context.Background()); err == nil {
+		t.Fatal("Ready() succeeded while issuer is down")
+	}
+	if url := provider.AuthCodeURL("state"); url != "" {
+		t.Errorf("AuthCodeURL() = %q before discovery", url)
+	}
+
+	// До истечения RetryInterval издатель не запрашивается повторно
+	f.down.Store(false)
+	if err := provider.Ready(context.Background()); err == nil {
+		t.Fatal("Ready() retried discovery before RetryInterval")
+	}
+
+	provider.RetryInterval = 0
+	if err := provider.Ready(context.Background()); err != nil {
+		t.Fatalf("Ready() error = %v after issuer is back", err)
+	}
+	if !strings.HasPrefix(provider.AuthCodeURL("state"), f.URL+"/authorize?") {
+		t.Errorf("AuthCodeURL() = %q", provider.AuthCodeURL("state"))
+	}
+}
@@ -0,0 +1,162 @@
+package authOauth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/SeiFlow-3P2/auth_service/internal/domain"
+	"golang.org/x/oauth2"
+)
+
+const (
+	GithubUserURL   = "https://api.github.com/user"
+	GithubEmailsURL = "https://api.github.com/user/emails"
+	GoogleUserURL   = "https://www.googleapis.com/oauth2/v3/userinfo"
+)
+
+var ErrEmailNotVerified = errors.New("provider email is not verified")
+
+// Provider is an OAuth2 provider with a profile endpoint.
+// All URLs and the HTTP client can be replaced, e.g. to point at a local fake server.
+type Provider struct {
+	Name        string
+	Config      *oauth2.Config
+	UserInfoURL string
+	EmailsURL   string
+	Client      *http.Client
+
+	parse func(ctx context.Context, p *Provider, client *http.Client) (*domain.UserInfo, error)
+}
+
+// NewGithub returns GitHub provider
+func NewGithub(config *oauth2.Config) *Provider {
+	return &Provider{
+		Name:        "github",
+		Config:      config,
+		UserInfoURL: GithubUserURL,
+		EmailsURL:   GithubEmailsURL,
+		parse:       parseGithub,
+	}
+}
+
+// NewGoogle returns Google provider
+func NewGoogle(config *oauth2.Config) *Provider {
+	return &Provider{
+		Name:        "google",
+		Config:      config,
+		UserInfoURL: GoogleUserURL,
+		parse:       parseGoogle,
+	}
+}
+
+func (p *Provider) context(ctx context.Context) context.Context {
+	if p.Client == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, oauth2.HTTPClient, p.Client)
+}
+
+// AuthCodeURL returns URL of the provider consent page
+func (p *Provider) AuthCodeURL(state string, opts ...oauth2.AuthCodeOption) string {
+	return p.Config.AuthCodeURL(state, opts...)
+}
+
+// Exchange exchanges authorization code for provider token
+func (p *Provider) Exchange(ctx context.Context, code string, opts ...oauth2.AuthCodeOption) (*oauth2.Token, error) {
+	return p.Config.Exchange(p.context(ctx), code, opts...)
+}
+
+// UserInfo fetches user profile with provider token
+func (p *Provider) UserInfo(ctx context.Context, token *oauth2.Token) (*domain.UserInfo, error) {
+	client := p.Config.Client(p.context(ctx), token)
+
+	info, err := p.parse(ctx, p, client)
+	if err != nil {
+		return nil, err
+	}
+	if info.Email == "" || !info.EmailVerified {
+		return nil, ErrEmailNotVerified
+	}
+	info.Provider = p.Name
+	return info, nil
+}
+
+func getJSON(ctx context.Context, client *http.Client, url string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s: unexpected status %d", url, resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+func parseGithub(ctx context.Context, p *Provider, client *http.Client) (*domain.UserInfo, error) {
+	var user struct {
+		ID        int64  `json:"id"`
+		Login     string `json:"login"`
+		Name      string `json:"name"`
+		AvatarURL string `json:"avatar_url"`
+	}
+	if err := getJSON(ctx, client, p.UserInfoURL, &user); err != nil {
+		return nil, err
+	}
+
+	// Публичный email в профиле может быть пустым или неподтверждённым, берём основной из /user/emails
+	var emails []struct {
+		Email    string `json:"email"`
+		Primary  bool   `json:"primary"`
+		Verified bool   `json:"verified"`
+	}
+	if err := getJSON(ctx, client, p.EmailsURL, &emails); err != nil {
+		return nil, err
+	}
+
+	info := &domain.UserInfo{
+		ID:        strconv.FormatInt(user.ID, 10),
+		Name:      user.Login,
+		AvatarURL: user.AvatarURL,
+	}
+	for _, e := range emails {
+		if e.Primary {
+			info.Email = e.Email
+			info.EmailVerified = e.Verified
+			break
+		}
+	}
+	return info, nil
+}
+
+func parseGoogle(ctx context.Context, p *Provider, client *http.Client) (*domain.UserInfo, error) {
+	var user struct {
+		Sub           string `json:"sub"`
+		Name          string `json:"name"`
+		Email         string `json:"email"`
+		EmailVerified bool   `json:"email_verified"`
+		Picture       string `json:"picture"`
+	}
+	if err := getJSON(ctx, client, p.UserInfoURL, &user); err != nil {
+		return nil, err
+	}
+
+	return &domain.UserInfo{
+		ID:            user.Sub,
+		Name:          user.Name,
+		Email:         user.Email,
+		EmailVerified: user.EmailVerified,
+		AvatarURL:     user.Picture,
+	}, nil
+}
@@ -0,0 +1,204 @@
+package authOauth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/SeiFlow-3P2/auth_service/internal/domain"
+	"golang.org/x/oauth2"
+)
+
+const (
+	testCode        = "test-code"
+	testAccessToken = "test-access-token"
+)
+
+// fakeOAuthServer is OAuth2 provider that exchanges testCode for testAccessToken
+// and serves JSON documents by path to requests with this token
+func fakeOAuthServer(t *testing.T, documents map[string]any) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /token", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("code") != testCode {
+			http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"access_token": testAccessToken, "token_type": "Bearer"})
+	})
+	for path, document := range documents {
+		mux.HandleFunc("GET "+path, func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "Bearer "+testAccessToken {
+				http.Error(w, "unauthorized", http.StatusUnauthorized)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(document)
+		})
+	}
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func testConfig(server *httptest.Server) *oauth2.Config {
+	return &oauth2.Config{
+		ClientID:     "client",
+		ClientSecret: "secret",
+		Endpoint:     oauth2.Endpoint{TokenURL: server.URL + "/token", AuthStyle: oauth2.AuthStyleInParams},
+	}
+}
+
+// login runs code exchange and profile requests against the fake server
+func login(t *testing.T, p *Provider) (*domain.UserInfo, error) {
+	t.Helper()
+	ctx := context.Background()
+	token, err := p.Exchange(ctx, testCode)
+	if err != nil {
+		t.Fatalf("Exchange() error = %v", err)
+	}
+	return p.UserInfo(ctx, token)
+}
+
+func TestGithub(t *testing.T) {
+	user := map[string]any{"id": 42, "login": "octocat", "name": "The Octocat", "avatar_url": "https://github.com/octocat.png"}
+
+	tests := []struct {
+		name      string
+		emails    []map[string]any
+		wantEmail string
+		wantErr   error
+	}{
+		{
+			name: "primary verified",
+			emails: []map[string]any{
+				{"email": "secondary@example.com", "primary": false, "verified": true},
+				{"email": "octocat@example.com", "primary": true, "verified": true},
+			},
+			wantEmail: "octocat@example.com",
+		},
+		{
+			name: "primary not verified",
+			emails: []map[string]any{
+				{"email": "octocat@example.com", "primary": true, "verified": false},
+				{"email": "secondary@example.com", "primary": false, "verified": true},
+			},
+			wantErr: ErrEmailNotVerified,
+		},
+		{
+			name: "no primary",
+			emails: []map[string]any{
+				{"email": "secondary@example.com", "primary": false, "verified": true},
+			},
+			wantErr: ErrEmailNotVerified,
+		},
+		{
+			name:    "no emails",
+			emails:  []map[string]any{},
+			wantErr: ErrEmailNotVerified,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := fakeOAuthServer(t, map[string]any{"/user": user, "/user/emails": tt.emails})
+			p := NewGithub(testConfig(server))
+			p.UserInfoURL = server.URL + "/user"
+			p.EmailsURL = server.URL + "/user/emails"
+			p.Client = server.Client()
+
+			info, err := login(t, p)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("UserInfo() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("UserInfo() error = %v", err)
+			}
+			want := domain.UserInfo{
+				ID:            "42",
+				Name:          "octocat",
+				Email:         tt.wantEmail,
+				EmailVerified: true,
+				AvatarURL:     "https://github.com/octocat.png",
+				Provider:      "github",
+			}
+			if *info != want {
+				t.Errorf("UserInfo() = %+v, want %+v", *info, want)
+			}
+		})
+	}
+}
+
+func TestGoogle(t *testing.T) {
+	tests := []struct {
+		name    string
+		user    map[string]any
+		wantErr error
+	}{
+		{
+			name: "email verified",
+			user: map[string]any{"sub": "1001", "name": "Ivan", "email": "ivan@example.com", "email_verified": true, "picture": "https://example.com/ivan.jpg"},
+		},
+		{
+			name:    "email not verified",
+			user:    map[string]any{"sub": "1001", "name": "Ivan", "email": "ivan@example.com", "email_verified": false},
+			wantErr: ErrEmailNotVerified,
+		},
+		{
+			name:    "no email_verified claim",
+			user:    map[string]any{"sub": "1001", "name": "Ivan", "email": "ivan@example.com"},
+			wantErr: ErrEmailNotVerified,
+		},
+		{
+			name:    "no email",
+			user:    map[string]any{"sub": "1001", "name": "Ivan", "email_verified": true},
+			wantErr: ErrEmailNotVerified,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := fakeOAuthServer(t, map[string]any{"/userinfo": tt.user})
+			p := NewGoogle(testConfig(server))
+			p.UserInfoURL = server.URL + "/userinfo"
+			p.Client = server.Client()
+
+			info, err := login(t, p)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("UserInfo() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("UserInfo() error = %v", err)
+			}
+			want := domain.UserInfo{
+				ID:            "1001",
+				Name:          "Ivan",
+				Email:         "ivan@example.com",
+				EmailVerified: true,
+				AvatarURL:     "https://example.com/ivan.jpg",
+				Provider:      "google",
+			}
+			if *info != want {
+				t.Errorf("UserInfo() = %+v, want %+v", *info, want)
+			}
+		})
+	}
+}
+
+func TestExchangeInvalidCode(t *testing.T) {
+	server := fakeOAuthServer(t, nil)
+	p := NewGoogle(testConfig(server))
+	p.Client = server.Client()
+
+	if _, err := p.Exchange(context.Background(), "other-code"); err == nil {
+		t.Fatal("Exchange() accepted unknown code")
+	}
+}
@@ -0,0 +1,146 @@
+package clientinfo
+
+import (
+	"context"
+	"net"
+	"net/netip"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// trustedProxies по умолчанию только loopback: grpc-gateway подключается к gRPC серверу локально
+var trustedProxies = []netip.Prefix{
+	netip.MustParsePrefix("127.0.0.0/8"),
+	netip.MustParsePrefix("::1/128"),
+}
+
+// SetTrustedProxies replaces networks of proxies whose x-forwarded-for is trusted.
+// Values are CIDRs or single addresses. Must be called before serving requests.
+func SetTrustedProxies(networks []string) error {
+	prefixes := make([]netip.Prefix, 0, len(networks))
+	for _, network := range networks {
+		network = strings.TrimSpace(network)
+		if network == "" {
+			continue
+		}
+		if !strings.Contains(network, "/") {
+			addr, err := netip.ParseAddr(network)
+			if err != nil {
+				return err
+			}
+			prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+			continue
+		}
+		prefix, err := netip.ParsePrefix(network)
+		if err != nil {
+			return err
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+	trustedProxies = prefixes
+	return nil
+}
+
+// trusted reports whether address belongs to a trusted proxy
+func trusted(ip string) bool {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, prefix := range trustedProxies {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// IP returns client address. Forwarding headers are used only when the gRPC peer
+// is a trusted proxy: x-forwarded-for is read from the right and the first hop
+// that is not a trusted proxy is the client, everything left of it can be forged.
+func IP(ctx context.Context) string {
+	ip := peerIP(ctx)
+	if !trusted(ip) {
+		return ip
+	}
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ip
+	}
+
+	var hops []string
+	for _, value := range md.Get("x-forwarded-for") {
+		for _, hop := range strings.Split(value, ",") {
+			if hop = strings.TrimSpace(hop); hop != "" {
+				hops = append(hops, hop)
+			}
+		}
+	}
+	for i := len(hops) - 1; i >= 0; i-- {
+		if !trusted(hops[i]) {
+			return hops[i]
+		}
+	}
+	// Все адреса цепочки принадлежат доверенным прокси
+	if len(hops) > 0 {
+		return hops[0]
+	}
+	if values := md.Get("x-real-ip"); len(values) > 0 {
+		if realIP := strings.TrimSpace(values[len(values)-1]); realIP != "" {
+			return realIP
+		}
+	}
+	return ip
+}
+
+// peerIP returns address of the gRPC peer
+func peerIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
+
+// Language returns the most preferred language from accept-language, e.g. "en-US"
+func Language(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	for _, key := range []string{"grpcgateway-accept-language", "accept-language"} {
+		values := md.Get(key)
+		if len(values) == 0 {
+			continue
+		}
+		// Языки обычно уже упорядочены по q, берём первый
+		first, _, _ := strings.Cut(values[0], ",")
+		lang, _, _ := strings.Cut(first, ";")
+		if lang = strings.TrimSpace(lang); lang != "" && lang != "*" {
+			return lang
+		}
+	}
+	return ""
+}
+
+// UserAgent returns client user agent
+func UserAgent(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	// grpc-gateway передаёт исходный заголовок с префиксом
+	for _, key := range []string{"grpcgateway-user-agent", "user-agent"} {
+		if values := md.Get(key); len(values) > 0 {
+			return values[0]
+		}
+	}
+	return ""
+}